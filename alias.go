@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	aliasParamsErr   = "Alias '%s' expects %d params, got %d on field '%s'"
+	aliasNoParamsErr = "Alias '%s' takes no params on field '%s'"
+)
+
+// aliasParamRegex matches the positional params of an alias eg. $1
+var aliasParamRegex = regexp.MustCompile(`\$[1-9][0-9]*`)
+
+// aliasParamCount returns the number of positional params of the tags of an alias,
+// the greatest of $1, $2...
+func aliasParamCount(tags string) int {
+
+	count := 0
+
+	for _, p := range aliasParamRegex.FindAllString(tags, -1) {
+		if n, _ := strconv.Atoi(p[1:]); n > count {
+			count = n
+		}
+	}
+
+	return count
+}
+
+// expandAlias returns the name and tags of the alias used by t, its positional params
+// replaced by those of t eg. 'range=1 10' for the alias 'min=$1,max=$2', and whether
+// t uses an alias at all.
+//
+// NOTE: panics if t doesn't pass as many params as the alias expects
+func (v *Validate) expandAlias(t string, fieldName string) (name string, tags string, found bool) {
+
+	if tags, found = v.aliases[t]; found {
+
+		if n := aliasParamCount(tags); n > 0 {
+			panic(strings.TrimSpace(fmt.Sprintf(aliasParamsErr, t, n, 0, fieldName)))
+		}
+
+		return t, tags, true
+	}
+
+	vals := strings.SplitN(t, tagKeySeparator, 2)
+
+	if len(vals) == 1 {
+		return "", "", false
+	}
+
+	name = vals[0]
+
+	if tags, found = v.aliases[name]; !found {
+		return "", "", false
+	}
+
+	n := aliasParamCount(tags)
+	if n == 0 {
+		panic(strings.TrimSpace(fmt.Sprintf(aliasNoParamsErr, name, fieldName)))
+	}
+
+	params := splitParamsRegex.FindAllString(vals[1], -1)
+	for i := range params {
+		params[i] = strings.Replace(params[i], "'", "", -1)
+	}
+
+	if len(params) != n {
+		panic(strings.TrimSpace(fmt.Sprintf(aliasParamsErr, name, n, len(params), fieldName)))
+	}
+
+	tags = aliasParamRegex.ReplaceAllStringFunc(tags, func(p string) string {
+		i, _ := strconv.Atoi(p[1:])
+		return params[i-1]
+	})
+
+	return name, tags, true
+}
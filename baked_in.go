@@ -31,6 +31,25 @@ type Func func(fl FieldLevel) bool
 // validation needs. The return value should be true when validation succeeds.
 type FuncCtx func(ctx context.Context, fl FieldLevel) bool
 
+// FuncErr accepts a context.Context and FieldLevel interface for all
+// validation needs. The return value should be nil when validation succeeds
+// and an error explaining the failure otherwise, kept as the Cause of the
+// resulting FieldError.
+type FuncErr func(ctx context.Context, fl FieldLevel) error
+
+// wrapFuncErr wraps FuncErr makes it compatible with FuncCtx, keeping the error
+// returned as the cause of the failure.
+func wrapFuncErr(fn FuncErr) FuncCtx {
+	if fn == nil {
+		return nil // be sure not to wrap a bad function.
+	}
+	return func(ctx context.Context, fl FieldLevel) bool {
+		err := fn(ctx, fl)
+		fl.(*validate).setCause(err)
+		return err == nil
+	}
+}
+
 // wrapFunc wraps noramal Func makes it compatible with FuncCtx
 func wrapFunc(fn Func) FuncCtx {
 	if fn == nil {
@@ -43,17 +62,19 @@ func wrapFunc(fn Func) FuncCtx {
 
 var (
 	restrictedTags = map[string]struct{}{
-		diveTag:           {},
-		keysTag:           {},
-		endKeysTag:        {},
-		structOnlyTag:     {},
-		omitempty:         {},
-		skipValidationTag: {},
-		utf8HexComma:      {},
-		utf8Pipe:          {},
-		noStructLevelTag:  {},
-		requiredTag:       {},
-		isdefault:         {},
+		diveTag:             {},
+		keysTag:             {},
+		endKeysTag:          {},
+		structOnlyTag:       {},
+		omitempty:           {},
+		skipValidationTag:   {},
+		utf8HexComma:        {},
+		utf8Pipe:            {},
+		noStructLevelTag:    {},
+		noValidateMethodTag: {},
+		methodTag:           {},
+		requiredTag:         {},
+		isdefault:           {},
 	}
 
 	// bakedInAliases is a default mapping of a single validation tag that
@@ -71,6 +92,9 @@ var (
 		"required":                      hasValue,
 		"required_if":                   requiredIf,
 		"required_unless":               requiredUnless,
+		"excluded_if":                   excludedIf,
+		"excluded_unless":               excludedUnless,
+		"required_when":                 requiredWhen,
 		"required_with":                 requiredWith,
 		"required_with_all":             requiredWithAll,
 		"required_without":              requiredWithout,
@@ -79,6 +103,7 @@ var (
 		"excluded_with_all":             excludedWithAll,
 		"excluded_without":              excludedWithout,
 		"excluded_without_all":          excludedWithoutAll,
+		"excluded_when":                 excludedWhen,
 		"isdefault":                     isDefault,
 		"len":                           hasLengthOf,
 		"min":                           hasMinOf,
@@ -198,6 +223,20 @@ var (
 		"postcode_iso3166_alpha2":       isPostcodeByIso3166Alpha2,
 		"postcode_iso3166_alpha2_field": isPostcodeByIso3166Alpha2Field,
 		"bic":                           isIsoBicFormat,
+		"before":                        isBefore,
+		"after":                         isAfter,
+		"future":                        isFuture,
+		"past":                          isPast,
+		"within":                        isWithin,
+		"weekday":                       isWeekday,
+		"date_only":                     isDateOnly,
+		"timezone_is":                   isTimezoneIs,
+		"decimal":                       isDecimal,
+		"multiple_of":                   isMultipleOf,
+		"min_num":                       isMinNum,
+		"max_num":                       isMaxNum,
+		"gt_num":                        isGtNum,
+		"lt_num":                        isLtNum,
 	}
 )
 
@@ -210,16 +249,23 @@ func parseOneOfParam2(s string) []string {
 	oneofValsCacheRWLock.RUnlock()
 	if !ok {
 		oneofValsCacheRWLock.Lock()
-		vals = splitParamsRegex.FindAllString(s, -1)
-		for i := 0; i < len(vals); i++ {
-			vals[i] = strings.Replace(vals[i], "'", "", -1)
-		}
+		vals = splitOneOfParam(s)
 		oneofValsCache[s] = vals
 		oneofValsCacheRWLock.Unlock()
 	}
 	return vals
 }
 
+// splitOneOfParam splits the space separated values of s, those within single quotes
+// may contain spaces.
+func splitOneOfParam(s string) []string {
+	vals := splitParamsRegex.FindAllString(s, -1)
+	for i := 0; i < len(vals); i++ {
+		vals[i] = strings.Replace(vals[i], "'", "", -1)
+	}
+	return vals
+}
+
 func isURLEncoded(fl FieldLevel) bool {
 	return uRLEncodedRegex.MatchString(fl.Field().String())
 }
@@ -233,7 +279,7 @@ func isHTML(fl FieldLevel) bool {
 }
 
 func isOneOf(fl FieldLevel) bool {
-	vals := parseOneOfParam2(fl.Param())
+	vals := paramsOf(fl)
 
 	field := fl.Field()
 
@@ -1230,7 +1276,7 @@ func isPostcodeByIso3166Alpha2(fl FieldLevel) bool {
 // example: `postcode_iso3166_alpha2_field=CountryCode`
 func isPostcodeByIso3166Alpha2Field(fl FieldLevel) bool {
 	field := fl.Field()
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 
 	if len(params) != 1 {
 		return false
@@ -1458,6 +1504,13 @@ func hasValue(fl FieldLevel) bool {
 	switch field.Kind() {
 	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
 		return !field.IsNil()
+	case reflect.Struct:
+		// numbers of math/big can't be compared to their zero value
+		if isBigNumberType(field.Type()) && !fl.(*validate).fldIsPointer {
+			r, ok := fieldRat(field)
+			return !ok || r.Sign() != 0
+		}
+		fallthrough
 	default:
 		if fl.(*validate).fldIsPointer && field.Interface() != nil {
 			return true
@@ -1472,7 +1525,7 @@ func requireCheckFieldKind(fl FieldLevel, param string, defaultNotFoundValue boo
 	kind := field.Kind()
 	var nullable, found bool
 	if len(param) > 0 {
-		field, kind, nullable, found = fl.GetStructFieldOKAdvanced2(fl.Parent(), param)
+		field, kind, nullable, found = fl.(*validate).getConditionalFieldOK(fl.Parent(), param)
 		if !found {
 			return defaultNotFoundValue
 		}
@@ -1492,7 +1545,7 @@ func requireCheckFieldKind(fl FieldLevel, param string, defaultNotFoundValue boo
 
 // requireCheckFieldValue is a func for check field value
 func requireCheckFieldValue(fl FieldLevel, param string, value string, defaultNotFoundValue bool) bool {
-	field, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), param)
+	field, kind, _, found := fl.(*validate).getConditionalFieldOK(fl.Parent(), param)
 	if !found {
 		return defaultNotFoundValue
 	}
@@ -1522,7 +1575,7 @@ func requireCheckFieldValue(fl FieldLevel, param string, value string, defaultNo
 // requiredIf is the validation function
 // The field under validation must be present and not empty only if all the other specified fields are equal to the value following with the specified field.
 func requiredIf(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	if len(params)%2 != 0 {
 		panic(fmt.Sprintf("Bad param number for required_if %s", fl.FieldName()))
 	}
@@ -1537,7 +1590,7 @@ func requiredIf(fl FieldLevel) bool {
 // requiredUnless is the validation function
 // The field under validation must be present and not empty only unless all the other specified fields are equal to the value following with the specified field.
 func requiredUnless(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	if len(params)%2 != 0 {
 		panic(fmt.Sprintf("Bad param number for required_unless %s", fl.FieldName()))
 	}
@@ -1550,10 +1603,42 @@ func requiredUnless(fl FieldLevel) bool {
 	return hasValue(fl)
 }
 
+// excludedIf is the validation function
+// The field under validation must not be present or is empty only if all the other specified fields are equal to the value following with the specified field.
+func excludedIf(fl FieldLevel) bool {
+	params := paramsOf(fl)
+	if len(params)%2 != 0 {
+		panic(fmt.Sprintf("Bad param number for excluded_if %s", fl.FieldName()))
+	}
+
+	for i := 0; i < len(params); i += 2 {
+		if !requireCheckFieldValue(fl, params[i], params[i+1], false) {
+			return true
+		}
+	}
+	return !hasValue(fl)
+}
+
+// excludedUnless is the validation function
+// The field under validation must not be present or is empty unless all the other specified fields are equal to the value following with the specified field.
+func excludedUnless(fl FieldLevel) bool {
+	params := paramsOf(fl)
+	if len(params)%2 != 0 {
+		panic(fmt.Sprintf("Bad param number for excluded_unless %s", fl.FieldName()))
+	}
+
+	for i := 0; i < len(params); i += 2 {
+		if !requireCheckFieldValue(fl, params[i], params[i+1], false) {
+			return !hasValue(fl)
+		}
+	}
+	return true
+}
+
 // excludedWith is the validation function
 // The field under validation must not be present or is empty if any of the other specified fields are present.
 func excludedWith(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	for _, param := range params {
 		if !requireCheckFieldKind(fl, param, true) {
 			return !hasValue(fl)
@@ -1565,7 +1650,7 @@ func excludedWith(fl FieldLevel) bool {
 // requiredWith is the validation function
 // The field under validation must be present and not empty only if any of the other specified fields are present.
 func requiredWith(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	for _, param := range params {
 		if !requireCheckFieldKind(fl, param, true) {
 			return hasValue(fl)
@@ -1577,7 +1662,7 @@ func requiredWith(fl FieldLevel) bool {
 // excludedWithAll is the validation function
 // The field under validation must not be present or is empty if all of the other specified fields are present.
 func excludedWithAll(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	for _, param := range params {
 		if requireCheckFieldKind(fl, param, true) {
 			return true
@@ -1589,7 +1674,7 @@ func excludedWithAll(fl FieldLevel) bool {
 // requiredWithAll is the validation function
 // The field under validation must be present and not empty only if all of the other specified fields are present.
 func requiredWithAll(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	for _, param := range params {
 		if requireCheckFieldKind(fl, param, true) {
 			return true
@@ -1619,7 +1704,7 @@ func requiredWithout(fl FieldLevel) bool {
 // excludedWithoutAll is the validation function
 // The field under validation must not be present or is empty when all of the other specified fields are not present.
 func excludedWithoutAll(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	for _, param := range params {
 		if !requireCheckFieldKind(fl, param, true) {
 			return true
@@ -1631,7 +1716,7 @@ func excludedWithoutAll(fl FieldLevel) bool {
 // requiredWithoutAll is the validation function
 // The field under validation must be present and not empty only when all of the other specified fields are not present.
 func requiredWithoutAll(fl FieldLevel) bool {
-	params := parseOneOfParam2(fl.Param())
+	params := paramsOf(fl)
 	for _, param := range params {
 		if !requireCheckFieldKind(fl, param, true) {
 			return true
@@ -1771,7 +1856,7 @@ func isGte(fl FieldLevel) bool {
 
 		if field.Type() == timeType {
 
-			now := time.Now().UTC()
+			now := fl.(*validate).now().UTC()
 			t := field.Interface().(time.Time)
 
 			return t.After(now) || t.Equal(now)
@@ -1817,7 +1902,7 @@ func isGt(fl FieldLevel) bool {
 
 		if field.Type() == timeType {
 
-			return field.Interface().(time.Time).After(time.Now().UTC())
+			return field.Interface().(time.Time).After(fl.(*validate).now().UTC())
 		}
 	}
 
@@ -1997,7 +2082,7 @@ func isLte(fl FieldLevel) bool {
 
 		if field.Type() == timeType {
 
-			now := time.Now().UTC()
+			now := fl.(*validate).now().UTC()
 			t := field.Interface().(time.Time)
 
 			return t.Before(now) || t.Equal(now)
@@ -2044,7 +2129,7 @@ func isLt(fl FieldLevel) bool {
 
 		if field.Type() == timeType {
 
-			return field.Interface().(time.Time).Before(time.Now().UTC())
+			return field.Interface().(time.Time).Before(fl.(*validate).now().UTC())
 		}
 	}
 
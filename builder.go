@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/haiyiyun/validator/rules"
+)
+
+// StructBuilder registers the rules of the fields of a struct type using the
+// typed rules of the rules package, see For.
+type StructBuilder struct {
+	v   *Validate
+	typ reflect.Type
+}
+
+// For returns a StructBuilder registering the rules of the fields of the struct
+// type of s, an alternative to tagging the struct.
+//
+//	validate.For(&User{}).
+//		Field("Name", rules.Required(), rules.Min(3)).
+//		Field("Emails", rules.Dive(rules.Email()))
+//
+// NOTES:
+// - panics if s is not a struct.
+// - this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) For(s interface{}) *StructBuilder {
+
+	typ, err := rulesType(s)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return &StructBuilder{v: v, typ: typ}
+}
+
+// Field registers the rules of the field, in order, replacing its tag as RegisterStructRules.
+// The rules are parsed straight away, so custom validations and aliases used with rules.Tag
+// must be registered beforehand.
+//
+// NOTE: panics if the field is not found within the struct or a rule is invalid or undefined.
+func (b *StructBuilder) Field(name string, rs ...rules.Rule) *StructBuilder {
+
+	if !hasStructField(b.typ, name) {
+		panic(fmt.Sprintf(undefinedRuleFld, name, b.typ))
+	}
+
+	tag := rules.Join(rs...)
+
+	if parsed, _ := extractNoValidateMethod(tag); len(parsed) > 0 && parsed != skipValidationTag {
+		b.v.parseFieldTagsRecursive(parsed, name, "", false)
+	}
+
+	b.v.registerTypeRules(b.typ, map[string]string{name: tag}, false)
+
+	return b
+}
+
+// StructLevel registers a StructLevelFunc for the struct type as RegisterStructValidation.
+func (b *StructBuilder) StructLevel(fn StructLevelFunc) *StructBuilder {
+	return b.StructLevelCtx(wrapStructLevelFunc(fn))
+}
+
+// StructLevelCtx registers a StructLevelFuncCtx for the struct type as RegisterStructValidationCtx.
+func (b *StructBuilder) StructLevelCtx(fn StructLevelFuncCtx) *StructBuilder {
+	b.v.RegisterStructValidationCtx(fn, reflect.Zero(b.typ).Interface())
+	return b
+}
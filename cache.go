@@ -20,11 +20,14 @@ const (
 	typeOr
 	typeKeys
 	typeEndKeys
+	typeGroup
 )
 
 const (
 	invalidValidation   = "Invalid validation tag on field '%s'"
 	undefinedValidation = "Undefined validation function '%s' on field '%s'"
+	invalidModifier     = "Invalid modifier tag on field '%s'"
+	undefinedModifier   = "Undefined modifier function '%s' on field '%s'"
 	keysTagNotDefined   = "'" + endKeysTag + "' tag encountered without a corresponding '" + keysTag + "' tag"
 )
 
@@ -48,6 +51,20 @@ func (sc *structCache) Set(key reflect.Type, value *cStruct) {
 	sc.m.Store(nm)
 }
 
+func (sc *structCache) Delete(key reflect.Type) {
+	m := sc.m.Load().(map[reflect.Type]*cStruct)
+	if _, ok := m[key]; !ok {
+		return
+	}
+	nm := make(map[reflect.Type]*cStruct, len(m))
+	for k, v := range m {
+		if k != key {
+			nm[k] = v
+		}
+	}
+	sc.m.Store(nm)
+}
+
 type tagCache struct {
 	lock sync.Mutex
 	m    atomic.Value // map[string]*cTag
@@ -75,11 +92,18 @@ type cStruct struct {
 }
 
 type cField struct {
-	idx        int
-	name       string
-	altName    string
-	namesEqual bool
-	cTags      *cTag
+	idx              int
+	name             string
+	altName          string
+	namesEqual       bool
+	cTags            *cTag
+	groups           []string            // only populated when using the 'groups' companion tag
+	mods             *cTag               // only populated when using the 'mod' companion tag
+	noValidateMethod bool                // set using the 'novalidatemethod' tag
+	methods          map[string]*cMethod // only populated when using the 'method' tag
+	messages         *cMessages          // only populated when using the 'msg' or 'errcode' companion tags
+	mayWrap          bool                // whether the values may be wrappers eg. sql.NullString, see extractFieldType
+	validateMethod   bool                // whether the values may have a Validate method, see hasValidateMethod
 }
 
 type cTag struct {
@@ -87,15 +111,20 @@ type cTag struct {
 	aliasTag             string
 	actualAliasTag       string
 	param                string
-	keys                 *cTag // only populated when using tag's 'keys' and 'endkeys' for map key validation
+	paramRef             string // only populated for params referencing a field eg. ${MaxItems} or the param source eg. @{limits.max}
+	keys                 *cTag  // only populated when using tag's 'keys' and 'endkeys' for map key validation
 	next                 *cTag
 	fn                   FuncCtx
+	expr                 *condExpr       // only populated for condition tags eg. required_when
+	group                *tagNode        // only populated for tag groups eg. (email|e164)
+	mod                  ModifierFuncCtx // only populated for modifier tags
 	typeof               tagType
 	hasTag               bool
 	hasAlias             bool
 	hasParam             bool // true if parameter used eg. eq= where the equal sign has been set
 	isBlockEnd           bool // indicates the current tag represents the last validation in the block
 	runValidationWhenNil bool
+	warn                 bool // failures are warnings, set within the 'warn' tag
 }
 
 func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStruct {
@@ -119,6 +148,7 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 	var fld reflect.StructField
 	var tag string
 	var customName string
+	var noValidateMethod bool
 
 	for i := 0; i < numFields; i++ {
 
@@ -128,12 +158,14 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 			continue
 		}
 
-		tag = fld.Tag.Get(v.tagName)
+		tag = v.fieldTag(typ, fld)
 
 		if tag == skipValidationTag {
 			continue
 		}
 
+		tag, noValidateMethod = extractNoValidateMethod(tag)
+
 		customName = fld.Name
 
 		if v.hasTagNameFunc {
@@ -155,11 +187,18 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 		}
 
 		cs.fields = append(cs.fields, &cField{
-			idx:        i,
-			name:       fld.Name,
-			altName:    customName,
-			cTags:      ctag,
-			namesEqual: fld.Name == customName,
+			idx:              i,
+			name:             fld.Name,
+			altName:          customName,
+			cTags:            ctag,
+			namesEqual:       fld.Name == customName,
+			groups:           parseGroups(fld.Tag.Get(groupsTagName)),
+			mods:             v.parseModifiers(fld.Tag.Get(modifierTagName), fld.Name),
+			noValidateMethod: noValidateMethod,
+			messages:         parseMessages(fld.Tag),
+			methods:          v.fieldMethods(typ, fld, ctag),
+			mayWrap:          v.mayWrap(fld.Type),
+			validateMethod:   v.hasValidateMethod(fld.Type),
 		})
 	}
 	v.structCache.Set(typ, cs)
@@ -169,7 +208,7 @@ func (v *Validate) extractStructCache(current reflect.Value, sName string) *cStr
 func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias string, hasAlias bool) (firstCtag *cTag, current *cTag) {
 	var t string
 	noAlias := len(alias) == 0
-	tags := strings.Split(tag, tagSeparator)
+	tags := splitTags(tag)
 
 	for i := 0; i < len(tags); i++ {
 		t = tags[i]
@@ -177,18 +216,45 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 			alias = t
 		}
 
+		if warnTags, found := extractWarnTags(t); found {
+			if i == 0 {
+				firstCtag, current = v.parseWarnTags(warnTags, fieldName)
+			} else {
+				next, curr := v.parseWarnTags(warnTags, fieldName)
+				current.next, current = next, curr
+			}
+			continue
+		}
+
 		// check map for alias and process new tags, otherwise process as usual
-		if tagsVal, found := v.aliases[t]; found {
+		if name, tagsVal, found := v.expandAlias(t, fieldName); found {
 			if i == 0 {
-				firstCtag, current = v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				firstCtag, current = v.parseFieldTagsRecursive(tagsVal, fieldName, name, true)
 			} else {
-				next, curr := v.parseFieldTagsRecursive(tagsVal, fieldName, t, true)
+				next, curr := v.parseFieldTagsRecursive(tagsVal, fieldName, name, true)
 				current.next, current = next, curr
 
 			}
 			continue
 		}
 
+		if isTagGroup(t) {
+
+			ct := &cTag{aliasTag: alias, hasAlias: hasAlias, hasTag: true, tag: t, typeof: typeGroup, isBlockEnd: true}
+			ct.group = v.parseTagGroup(t, fieldName)
+
+			if !hasAlias {
+				ct.aliasTag = t
+			}
+
+			if i == 0 {
+				firstCtag, current = ct, ct
+			} else {
+				current.next, current = ct, ct
+			}
+			continue
+		}
+
 		var prevTag tagType
 
 		if i == 0 {
@@ -260,7 +326,13 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				current.typeof = typeIsDefault
 			}
 			// if a pipe character is needed within the param you must use the utf8Pipe representation "0x7C"
-			orVals := strings.Split(t, orSeparator)
+			// except for condition expressions which use '||' as their or operator.
+			var orVals []string
+			if _, ok := conditionTags[strings.SplitN(t, tagKeySeparator, 2)[0]]; ok {
+				orVals = []string{t}
+			} else {
+				orVals = strings.Split(t, orSeparator)
+			}
 
 			for j := 0; j < len(orVals); j++ {
 				vals := strings.SplitN(orVals[j], tagKeySeparator, 2)
@@ -296,6 +368,16 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 				if len(vals) > 1 {
 					current.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
 				}
+
+				if _, ok := conditionTags[current.tag]; ok {
+					expr, err := parseCondExpr(current.param)
+					if err != nil {
+						panic(strings.TrimSpace(fmt.Sprintf(invalidExpression, current.param, fieldName, err)))
+					}
+					current.expr = expr
+				}
+
+				bindParamRef(current)
 			}
 			current.isBlockEnd = true
 		}
@@ -303,6 +385,17 @@ func (v *Validate) parseFieldTagsRecursive(tag string, fieldName string, alias s
 	return
 }
 
+// parseGroups splits the value of the 'groups' companion tag into the names of
+// the groups the field's validations belong to.
+func parseGroups(tag string) (groups []string) {
+	for _, g := range strings.Split(tag, tagSeparator) {
+		if g = strings.TrimSpace(g); len(g) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return
+}
+
 func (v *Validate) fetchCacheTag(tag string) *cTag {
 	// find cached tag
 	ctag, found := v.tagCache.Get(tag)
@@ -0,0 +1,563 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	checkUndefined  = "undefined validation function '%s'"
+	checkEmptyTag   = "empty validation tag"
+	checkKind       = "'%s' cannot be used on %s"
+	checkParam      = "invalid param '%s' for %s"
+	checkNoField    = "field '%s' does not exist"
+	checkParamCount = "'%s' requires field and value pairs"
+	checkDive       = "'" + diveTag + "' cannot be used on %s"
+	checkKeys       = "'" + keysTag + "' cannot be used on %s"
+)
+
+var (
+	// fieldParamTags are the tags whose param is the namespace of another field
+	fieldParamTags = map[string]struct{}{
+		"eqfield":                       {},
+		"nefield":                       {},
+		"gtfield":                       {},
+		"gtefield":                      {},
+		"ltfield":                       {},
+		"ltefield":                      {},
+		"eqcsfield":                     {},
+		"necsfield":                     {},
+		"gtcsfield":                     {},
+		"gtecsfield":                    {},
+		"ltcsfield":                     {},
+		"ltecsfield":                    {},
+		"fieldcontains":                 {},
+		"fieldexcludes":                 {},
+		"postcode_iso3166_alpha2_field": {},
+		requiredWithoutTag:              {},
+		excludedWithoutTag:              {},
+	}
+
+	// fieldListTags are the tags whose param is a list of field namespaces
+	fieldListTags = map[string]struct{}{
+		requiredWithTag:       {},
+		requiredWithAllTag:    {},
+		requiredWithoutAllTag: {},
+		excludedWithTag:       {},
+		excludedWithAllTag:    {},
+		excludedWithoutAllTag: {},
+	}
+
+	// fieldValueTags are the tags whose param is a list of field namespace and value pairs
+	fieldValueTags = map[string]struct{}{
+		requiredIfTag:     {},
+		requiredUnlessTag: {},
+		excludedIfTag:     {},
+		excludedUnlessTag: {},
+	}
+)
+
+// TagError describes a problem with the validation tags of a struct field
+// found by Check.
+type TagError struct {
+	Type    reflect.Type // struct type of the field
+	Field   string       // struct field name
+	Tag     string       // offending tag
+	Problem string       // what is wrong with the tag
+}
+
+// Error returns the TagError message
+func (e *TagError) Error() string {
+	return fmt.Sprintf("validator: %s.%s tag '%s': %s", e.Type.String(), e.Field, e.Tag, e.Problem)
+}
+
+// TagErrors is an array of TagError's returned by Check.
+type TagErrors []*TagError
+
+// Error returns the messages of all of the TagErrors, one per line.
+func (te TagErrors) Error() string {
+
+	buff := bytes.NewBufferString("")
+
+	for i := 0; i < len(te); i++ {
+		buff.WriteString(te[i].Error())
+		buff.WriteString("\n")
+	}
+
+	return strings.TrimSpace(buff.String())
+}
+
+// Check eagerly parses and caches the validation tags of the struct types of
+// the values passed in, along with all of the struct types they reference,
+// instead of panicking the first time a struct with bad tags is validated.
+//
+// It returns InvalidValidationError for bad values passed in and nil or
+// TagErrors as error otherwise, holding every problem found eg. undefined
+// validations, cross field tags naming fields that do not exist or tags used
+// on kinds of fields they cannot validate.
+//
+// NOTE: validations, aliases and custom types must be registered prior to calling Check.
+func (v *Validate) Check(types ...interface{}) error {
+
+	c := &tagChecker{v: v, seen: make(map[reflect.Type]struct{})}
+
+	for _, t := range types {
+
+		typ := reflect.TypeOf(t)
+
+		if typ == nil || derefType(typ).Kind() != reflect.Struct || derefType(typ) == timeType {
+			return &InvalidValidationError{Type: reflect.TypeOf(t)}
+		}
+
+		c.checkStruct(derefType(typ))
+	}
+
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+
+	return nil
+}
+
+// tagChecker holds the state of a single Check.
+type tagChecker struct {
+	v    *Validate
+	seen map[reflect.Type]struct{}
+	errs TagErrors
+}
+
+func (c *tagChecker) report(typ reflect.Type, field string, tag string, problem string) {
+	c.errs = append(c.errs, &TagError{Type: typ, Field: field, Tag: tag, Problem: problem})
+}
+
+// checkStruct checks the tags of the fields of typ, caching it when no problems
+// are found, and of the struct types it references.
+func (c *tagChecker) checkStruct(typ reflect.Type) {
+
+	if _, ok := c.seen[typ]; ok {
+		return
+	}
+	c.seen[typ] = struct{}{}
+
+	errs := len(c.errs)
+	var nested []reflect.Type
+
+	for i := 0; i < typ.NumField(); i++ {
+
+		fld := typ.Field(i)
+
+		if !fld.Anonymous && len(fld.PkgPath) > 0 {
+			continue
+		}
+
+		tag := c.v.fieldTag(typ, fld)
+
+		if tag == skipValidationTag {
+			continue
+		}
+
+		tag, _ = extractNoValidateMethod(tag)
+
+		if len(tag) > 0 && c.checkTagNames(typ, fld.Name, tag) {
+
+			var ct *cTag
+
+			if msg := recoverPanic(func() { ct, _ = c.v.parseFieldTagsRecursive(tag, fld.Name, "", false) }); len(msg) > 0 {
+				c.report(typ, fld.Name, tag, msg)
+			} else if msg = recoverPanic(func() { c.v.fieldMethods(typ, fld, ct) }); len(msg) > 0 {
+				c.report(typ, fld.Name, tag, msg)
+			} else {
+				c.checkChain(typ, fld.Name, fld.Type, ct)
+			}
+		}
+
+		if mods := fld.Tag.Get(modifierTagName); len(mods) > 0 {
+			if msg := recoverPanic(func() { c.v.parseModifiers(mods, fld.Name) }); len(msg) > 0 {
+				c.report(typ, fld.Name, mods, msg)
+			}
+		}
+
+		nested = appendNestedStructs(nested, fld.Type)
+	}
+
+	// only cache structs that won't panic, leaving them to be reported again
+	// if validated anyway
+	if len(c.errs) == errs {
+		if _, ok := c.v.structCache.Get(typ); !ok {
+			c.v.extractStructCache(reflect.New(typ).Elem(), typ.Name())
+		}
+	}
+
+	for _, n := range nested {
+		c.checkStruct(n)
+	}
+}
+
+// checkTagNames reports undefined and empty validations of the field, returning
+// whether the tag can be parsed.
+func (c *tagChecker) checkTagNames(typ reflect.Type, field string, tag string) bool {
+
+	ok := true
+
+	for _, t := range splitTags(tag) {
+
+		if warnTags, found := extractWarnTags(t); found {
+			if !c.checkTagNames(typ, field, warnTags) {
+				ok = false
+			}
+			continue
+		}
+
+		switch t {
+		case diveTag, keysTag, endKeysTag, omitempty, structOnlyTag, noStructLevelTag:
+			continue
+		}
+
+		// the tags of groups are checked when parsing them
+		if isTagGroup(t) {
+			continue
+		}
+
+		if _, found := c.v.aliases[strings.SplitN(t, tagKeySeparator, 2)[0]]; found {
+			continue
+		}
+
+		var orVals []string
+		if _, found := conditionTags[strings.SplitN(t, tagKeySeparator, 2)[0]]; found {
+			orVals = []string{t}
+		} else {
+			orVals = strings.Split(t, orSeparator)
+		}
+
+		for _, o := range orVals {
+
+			name := strings.SplitN(o, tagKeySeparator, 2)[0]
+
+			if len(name) == 0 {
+				c.report(typ, field, t, checkEmptyTag)
+				ok = false
+				continue
+			}
+
+			if _, found := c.v.validations[name]; !found {
+				c.report(typ, field, o, fmt.Sprintf(checkUndefined, name))
+				ok = false
+			}
+		}
+	}
+
+	return ok
+}
+
+// checkChain checks the parsed tags of a field of the struct typ against the
+// field's type, following dive to the elements.
+func (c *tagChecker) checkChain(typ reflect.Type, field string, fieldType reflect.Type, ct *cTag) {
+
+	fieldType = derefType(fieldType)
+
+	for ; ct != nil; ct = ct.next {
+
+		switch ct.typeof {
+
+		case typeDive:
+
+			switch fieldType.Kind() {
+			case reflect.Slice, reflect.Array:
+
+				if ct.next != nil && ct.next.typeof == typeKeys {
+					c.report(typ, field, keysTag, fmt.Sprintf(checkKeys, fieldType))
+					return
+				}
+
+			case reflect.Map:
+
+				if ct.next != nil && ct.next.typeof == typeKeys {
+					ct = ct.next
+					c.checkChain(typ, field, fieldType.Key(), ct.keys)
+				}
+
+			case reflect.Interface:
+				return
+
+			default:
+				c.report(typ, field, diveTag, fmt.Sprintf(checkDive, fieldType))
+				return
+			}
+
+			c.checkChain(typ, field, fieldType.Elem(), ct.next)
+			return
+
+		case typeDefault, typeOr, typeIsDefault:
+			if ct.hasTag {
+				c.checkTag(typ, field, fieldType, ct)
+			}
+
+		case typeGroup:
+			for _, leaf := range ct.group.leaves(nil) {
+				c.checkTag(typ, field, fieldType, leaf)
+			}
+		}
+	}
+}
+
+// checkTag checks a single validation of a field of the struct typ.
+func (c *tagChecker) checkTag(typ reflect.Type, field string, fieldType reflect.Type, ct *cTag) {
+
+	tag := ct.tag
+	if ct.hasParam {
+		tag += tagKeySeparator + ct.param
+	}
+
+	// params referencing the param source are only known when validating
+	if len(ct.paramRef) > 0 {
+		if ct.paramRef[0] == fieldParamPrefix {
+			c.checkFieldNames(typ, field, tag, paramRefKey(ct.paramRef))
+		}
+		return
+	}
+
+	// the fields referenced are looked up from the struct containing the field
+	if _, ok := fieldParamTags[ct.tag]; ok {
+		c.checkFieldNames(typ, field, tag, strings.TrimSpace(ct.param))
+		return
+	}
+
+	if _, ok := fieldListTags[ct.tag]; ok {
+		c.checkFieldNames(typ, field, tag, parseOneOfParam2(ct.param)...)
+		return
+	}
+
+	if _, ok := fieldValueTags[ct.tag]; ok {
+
+		params := parseOneOfParam2(ct.param)
+		if len(params)%2 != 0 {
+			c.report(typ, field, tag, fmt.Sprintf(checkParamCount, ct.tag))
+			return
+		}
+
+		for i := 0; i < len(params); i += 2 {
+			c.checkFieldNames(typ, field, tag, params[i])
+		}
+		return
+	}
+
+	if ct.expr != nil {
+		c.checkFieldNames(typ, field, tag, ct.expr.fields(nil)...)
+		return
+	}
+
+	// the kind of fields using custom types or interfaces is only known when validating
+	if fieldType.Kind() == reflect.Interface {
+		return
+	}
+
+	if _, ok := c.v.customFuncs[fieldType]; ok {
+		return
+	}
+
+	if w, _ := c.v.wrapperOf(fieldType); w != noWrapper {
+		return
+	}
+
+	switch ct.tag {
+	case "len", "min", "max", "eq", "ne", "lt", "lte", "gt", "gte":
+		c.checkKindParam(typ, field, tag, fieldType, ct)
+	case "oneof":
+		switch fieldType.Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			c.report(typ, field, tag, fmt.Sprintf(checkKind, ct.tag, fieldType))
+		}
+	}
+}
+
+// checkKindParam checks the kind of field and param of the comparison tags.
+func (c *tagChecker) checkKindParam(typ reflect.Type, field string, tag string, fieldType reflect.Type, ct *cTag) {
+
+	var err error
+
+	switch fieldType.Kind() {
+
+	case reflect.String:
+
+		// strings are compared to the param by eq and ne, and their length otherwise
+		if ct.tag == "eq" || ct.tag == "ne" {
+			return
+		}
+		_, err = strconv.ParseInt(ct.param, 0, 64)
+
+	case reflect.Slice, reflect.Map, reflect.Array:
+		_, err = strconv.ParseInt(ct.param, 0, 64)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, err = strconv.ParseInt(ct.param, 0, 64); err != nil && fieldType == timeDurationType {
+			_, err = time.ParseDuration(ct.param)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		_, err = strconv.ParseUint(ct.param, 0, 64)
+
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(ct.param, 64)
+
+	case reflect.Bool:
+		if ct.tag != "eq" && ct.tag != "ne" {
+			c.report(typ, field, tag, fmt.Sprintf(checkKind, ct.tag, fieldType))
+			return
+		}
+		_, err = strconv.ParseBool(ct.param)
+
+	case reflect.Struct:
+
+		// the tags of structs other than time.Time are not run
+		if fieldType != timeType {
+			return
+		}
+
+		// time.Time is only compared to the current time
+		switch ct.tag {
+		case "min", "max", "lt", "lte", "gt", "gte":
+			return
+		}
+
+		c.report(typ, field, tag, fmt.Sprintf(checkKind, ct.tag, fieldType))
+		return
+
+	default:
+		c.report(typ, field, tag, fmt.Sprintf(checkKind, ct.tag, fieldType))
+		return
+	}
+
+	if err != nil {
+		c.report(typ, field, tag, fmt.Sprintf(checkParam, ct.param, fieldType))
+	}
+}
+
+// checkFieldNames reports the namespaces that do not exist within typ, namespaces
+// relative to the enclosing structs are only known when validating.
+func (c *tagChecker) checkFieldNames(typ reflect.Type, field string, tag string, namespaces ...string) {
+	for _, ns := range namespaces {
+		if len(ns) == 0 || strings.HasPrefix(ns, parentNamespacePrefix) {
+			continue
+		}
+		if !typeHasField(typ, ns) {
+			c.report(typ, field, tag, fmt.Sprintf(checkNoField, ns))
+		}
+	}
+}
+
+// fields returns the namespaces of the fields referenced by the expression.
+func (e *condExpr) fields(fields []string) []string {
+
+	if e == nil {
+		return fields
+	}
+
+	if e.op == condOpField {
+		return append(fields, e.field)
+	}
+
+	fields = e.left.fields(fields)
+	fields = e.right.fields(fields)
+
+	for _, item := range e.list {
+		fields = item.fields(fields)
+	}
+
+	return fields
+}
+
+// typeHasField reports whether the namespace, as used by getStructFieldOKInternal,
+// can exist within typ.
+func typeHasField(typ reflect.Type, namespace string) bool {
+
+	for {
+
+		typ = derefType(typ)
+
+		if len(namespace) == 0 {
+			return true
+		}
+
+		switch typ.Kind() {
+
+		case reflect.Interface:
+			return true
+
+		case reflect.Struct:
+
+			if typ == timeType {
+				return false
+			}
+
+			fld := namespace
+			idx := strings.IndexAny(namespace, namespaceSeparator+leftBracket)
+
+			if idx != -1 {
+				fld = namespace[:idx]
+				namespace = strings.TrimPrefix(namespace[idx:], namespaceSeparator)
+			} else {
+				namespace = ""
+			}
+
+			sf, ok := typ.FieldByName(fld)
+			if !ok {
+				return false
+			}
+			typ = sf.Type
+
+		case reflect.Slice, reflect.Array, reflect.Map:
+
+			if !strings.HasPrefix(namespace, leftBracket) {
+				return false
+			}
+
+			idx := strings.Index(namespace, rightBracket)
+			if idx == -1 {
+				return false
+			}
+
+			namespace = strings.TrimPrefix(namespace[idx+1:], namespaceSeparator)
+			typ = typ.Elem()
+
+		default:
+			return false
+		}
+	}
+}
+
+// appendNestedStructs appends the struct types referenced by typ, through
+// pointers, slices, arrays and maps.
+func appendNestedStructs(nested []reflect.Type, typ reflect.Type) []reflect.Type {
+
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		case reflect.Struct:
+			if typ != timeType {
+				nested = append(nested, typ)
+			}
+			return nested
+		default:
+			return nested
+		}
+	}
+}
+
+// recoverPanic runs fn returning the message of any panic.
+func recoverPanic(fn func()) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = fmt.Sprint(r)
+		}
+	}()
+	fn()
+	return
+}
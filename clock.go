@@ -0,0 +1,287 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	nowParam     = "now"
+	dateLayout   = "2006-01-02"
+	badWeekday   = "Bad weekday '%s' in param '%s'"
+	badTimeParam = "Bad time param '%s'"
+)
+
+var (
+	weekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "sunday": time.Sunday,
+		"mon": time.Monday, "monday": time.Monday,
+		"tue": time.Tuesday, "tuesday": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday,
+		"thu": time.Thursday, "thursday": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday,
+	}
+
+	// locations caches the locations of the 'timezone_is' params, keyed by name
+	locations sync.Map
+)
+
+// SetClock sets the function returning the current time used by every time based
+// validation eg. 'future', 'after=now-24h' and 'gt' on time.Time, a nil clock using
+// time.Now.
+//
+// It can be overridden per validation using WithClock.
+//
+// NOTE: this method is not thread-safe it is intended that it be set prior to any validation
+func (v *Validate) SetClock(clock func() time.Time) {
+	v.clock = clock
+}
+
+// WithClock returns a copy of ctx setting the function returning the current time
+// used by the validations it is passed to, overriding the one set using SetClock.
+func WithClock(ctx context.Context, clock func() time.Time) context.Context {
+	return context.WithValue(ctx, clockCtxKey, clock)
+}
+
+// clockFor returns the clock of a validation using ctx.
+func (v *Validate) clockFor(ctx context.Context) func() time.Time {
+	if ctx != nil {
+		if clock, ok := ctx.Value(clockCtxKey).(func() time.Time); ok && clock != nil {
+			return clock
+		}
+	}
+	return v.clock
+}
+
+// now returns the current time of the validation.
+func (v *validate) now() time.Time {
+	if v.clock != nil {
+		return v.clock()
+	}
+	return time.Now()
+}
+
+// timeField returns the time of the field, a time.Time or an RFC 3339 string, and
+// whether a string could be parsed.
+func timeField(field reflect.Value) (time.Time, bool) {
+
+	switch field.Kind() {
+
+	case reflect.String:
+		t, err := time.Parse(time.RFC3339Nano, field.String())
+		return t, err == nil
+
+	case reflect.Struct:
+		if field.Type() == timeType {
+			return field.Interface().(time.Time), true
+		}
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// asTime returns the time of the param, either an RFC 3339 time, a date or a time
+// relative to the current time eg. 'now', 'now+72h' or 'now-30m'.
+//
+// NOTE: panics if the param is none of those
+func asTime(fl FieldLevel, param string) time.Time {
+
+	if strings.HasPrefix(param, nowParam) {
+
+		now := fl.(*validate).now()
+		offset := param[len(nowParam):]
+
+		if len(offset) == 0 {
+			return now
+		}
+
+		if offset[0] != '+' && offset[0] != '-' {
+			panic(fmt.Sprintf(badTimeParam, param))
+		}
+
+		d, err := time.ParseDuration(offset)
+		panicIf(err)
+
+		return now.Add(d)
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, param); err == nil {
+		return t
+	}
+
+	t, err := time.Parse(dateLayout, param)
+	if err != nil {
+		panic(fmt.Sprintf(badTimeParam, param))
+	}
+
+	return t
+}
+
+// isBefore is the validation function for validating if the current field's time is before
+// the param's time.
+func isBefore(fl FieldLevel) bool {
+
+	t, ok := timeField(fl.Field())
+
+	return ok && t.Before(asTime(fl, fl.Param()))
+}
+
+// isAfter is the validation function for validating if the current field's time is after
+// the param's time.
+func isAfter(fl FieldLevel) bool {
+
+	t, ok := timeField(fl.Field())
+
+	return ok && t.After(asTime(fl, fl.Param()))
+}
+
+// isFuture is the validation function for validating if the current field's time is in
+// the future.
+func isFuture(fl FieldLevel) bool {
+
+	t, ok := timeField(fl.Field())
+
+	return ok && t.After(fl.(*validate).now())
+}
+
+// isPast is the validation function for validating if the current field's time is in
+// the past.
+func isPast(fl FieldLevel) bool {
+
+	t, ok := timeField(fl.Field())
+
+	return ok && t.Before(fl.(*validate).now())
+}
+
+// isWithin is the validation function for validating if the current field's time is
+// within the param's duration of the current time, in the past or the future.
+func isWithin(fl FieldLevel) bool {
+
+	t, ok := timeField(fl.Field())
+	if !ok {
+		return false
+	}
+
+	d := time.Duration(asIntFromTimeDuration(fl.Param()))
+	if d < 0 {
+		d = -d
+	}
+
+	diff := t.Sub(fl.(*validate).now())
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff <= d
+}
+
+// isWeekday is the validation function for validating if the current field's time falls on
+// one of the param's days, in the time's location, separated by spaces or ranges of days
+// eg. 'mon-fri' or 'sat sun'.
+func isWeekday(fl FieldLevel) bool {
+
+	t, ok := timeField(fl.Field())
+	if !ok {
+		return false
+	}
+
+	day := t.Weekday()
+	param := fl.Param()
+
+	for _, days := range strings.Fields(param) {
+
+		bounds := strings.SplitN(days, "-", 2)
+
+		from := parseWeekday(bounds[0], param)
+		to := from
+
+		if len(bounds) == 2 {
+			to = parseWeekday(bounds[1], param)
+		}
+
+		// ranges may wrap around the end of the week eg. 'fri-mon'
+		if from <= to && day >= from && day <= to {
+			return true
+		}
+
+		if from > to && (day >= from || day <= to) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseWeekday returns the weekday of its name, or its first 3 letters.
+//
+// NOTE: panics if it is not the name of a weekday
+func parseWeekday(name string, param string) time.Weekday {
+
+	day, ok := weekdays[strings.ToLower(name)]
+	if !ok {
+		panic(fmt.Sprintf(badWeekday, name, param))
+	}
+
+	return day
+}
+
+// isDateOnly is the validation function for validating if the current field's value is a
+// date without a time of day, a time.Time at midnight or a string of the 2006-01-02 format.
+func isDateOnly(fl FieldLevel) bool {
+
+	field := fl.Field()
+
+	if field.Kind() == reflect.String {
+		_, err := time.Parse(dateLayout, field.String())
+		return err == nil
+	}
+
+	t, _ := timeField(field)
+
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// isTimezoneIs is the validation function for validating if the current field's time is in the
+// param's time zone, either its location or its offset at that time being the zone's.
+func isTimezoneIs(fl FieldLevel) bool {
+
+	t, ok := timeField(fl.Field())
+	if !ok {
+		return false
+	}
+
+	param := fl.Param()
+
+	if t.Location().String() == param {
+		return true
+	}
+
+	loc := loadLocation(param)
+
+	_, offset := t.Zone()
+	_, zoneOffset := t.In(loc).Zone()
+
+	return offset == zoneOffset
+}
+
+// loadLocation returns the cached location of the name.
+//
+// NOTE: panics if the name is not a time zone of the time zone database
+func loadLocation(name string) *time.Location {
+
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	panicIf(err)
+
+	locations.Store(name, loc)
+
+	return loc
+}
@@ -0,0 +1,608 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	invalidExpression = "Invalid expression '%s' on field '%s': %s"
+)
+
+// conditionTags accept a boolean expression as their parameter which is
+// parsed once when the tag is cached, eg.
+//
+//	required_when=Status=='active' && (Age>=18 || Guardian!='')
+var conditionTags = map[string]struct{}{
+	requiredWhenTag: {},
+	excludedWhenTag: {},
+}
+
+type condOp uint8
+
+const (
+	condOpField condOp = iota // operand only, truthy when the value is set
+	condOpLiteral
+	condOpNot
+	condOpAnd
+	condOpOr
+	condOpEq
+	condOpNe
+	condOpLt
+	condOpLte
+	condOpGt
+	condOpGte
+	condOpIn
+	condOpNotIn
+)
+
+// condExpr is a node of a parsed condition expression.
+type condExpr struct {
+	op    condOp
+	left  *condExpr
+	right *condExpr
+	list  []*condExpr // only populated for 'in' and 'not in'
+	field string      // namespace of the referenced field, see getConditionalFieldOK
+	lit   condValue   // only populated for literals
+}
+
+type condValueKind uint8
+
+const (
+	condNil condValueKind = iota
+	condString
+	condNumber
+	condBool
+	condOther
+)
+
+// condValue is an operand of a condition after the referenced field has been
+// resolved, numbers are compared as float64 and slices, arrays and maps by their
+// length.
+type condValue struct {
+	kind  condValueKind
+	str   string
+	num   float64
+	b     bool
+	isNil bool
+	raw   string // literal as written, used to compare numeric literals against strings
+}
+
+// eval evaluates the expression for the fields of parent.
+func (e *condExpr) eval(v *validate, parent reflect.Value) bool {
+
+	switch e.op {
+
+	case condOpNot:
+		return !e.left.eval(v, parent)
+
+	case condOpAnd:
+		return e.left.eval(v, parent) && e.right.eval(v, parent)
+
+	case condOpOr:
+		return e.left.eval(v, parent) || e.right.eval(v, parent)
+
+	case condOpField, condOpLiteral:
+		return e.value(v, parent).truthy()
+
+	case condOpIn, condOpNotIn:
+
+		l := e.left.value(v, parent)
+
+		for _, item := range e.list {
+			if l.equal(item.value(v, parent)) {
+				return e.op == condOpIn
+			}
+		}
+
+		return e.op == condOpNotIn
+	}
+
+	l, r := e.left.value(v, parent), e.right.value(v, parent)
+
+	switch e.op {
+	case condOpEq:
+		return l.equal(r)
+	case condOpNe:
+		return !l.equal(r)
+	}
+
+	cmp, ok := l.compare(r)
+	if !ok {
+		return false
+	}
+
+	switch e.op {
+	case condOpLt:
+		return cmp < 0
+	case condOpLte:
+		return cmp <= 0
+	case condOpGt:
+		return cmp > 0
+	default: // condOpGte
+		return cmp >= 0
+	}
+}
+
+// value resolves the operand e.
+func (e *condExpr) value(v *validate, parent reflect.Value) condValue {
+
+	if e.op == condOpLiteral {
+		return e.lit
+	}
+
+	current, kind, _, found := v.getConditionalFieldOK(parent, e.field)
+	if !found {
+		return condValue{kind: condNil, isNil: true}
+	}
+
+	switch kind {
+
+	case reflect.Ptr, reflect.Interface, reflect.Invalid:
+		return condValue{kind: condNil, isNil: true}
+
+	case reflect.String:
+		return condValue{kind: condString, str: current.String()}
+
+	case reflect.Bool:
+		return condValue{kind: condBool, b: current.Bool()}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return condValue{kind: condNumber, num: float64(current.Int())}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return condValue{kind: condNumber, num: float64(current.Uint())}
+
+	case reflect.Float32, reflect.Float64:
+		return condValue{kind: condNumber, num: current.Float()}
+
+	case reflect.Slice, reflect.Map:
+		return condValue{kind: condNumber, num: float64(current.Len()), isNil: current.IsNil()}
+
+	case reflect.Array:
+		return condValue{kind: condNumber, num: float64(current.Len())}
+	}
+
+	return condValue{kind: condOther, b: !current.IsZero()}
+}
+
+// truthy reports if the value is set, the same as the 'required' tag.
+func (c condValue) truthy() bool {
+	switch c.kind {
+	case condString:
+		return len(c.str) > 0
+	case condNumber:
+		return c.num != 0 && !c.isNil
+	case condBool, condOther:
+		return c.b
+	}
+	return false
+}
+
+func (c condValue) equal(o condValue) bool {
+
+	if c.kind == condNil || o.kind == condNil {
+		return c.isNil == o.isNil
+	}
+
+	if c.kind != o.kind {
+		// numeric literal compared against a string field eg. Code=='01' or Code==1
+		if c.kind == condString && o.kind == condNumber && len(o.raw) > 0 {
+			return c.str == o.raw
+		}
+		if o.kind == condString && c.kind == condNumber && len(c.raw) > 0 {
+			return o.str == c.raw
+		}
+		return false
+	}
+
+	switch c.kind {
+	case condString:
+		return c.str == o.str
+	case condNumber:
+		return c.num == o.num
+	case condBool:
+		return c.b == o.b
+	}
+	return false
+}
+
+func (c condValue) compare(o condValue) (int, bool) {
+
+	if c.kind != o.kind {
+		return 0, false
+	}
+
+	switch c.kind {
+	case condString:
+		return strings.Compare(c.str, o.str), true
+	case condNumber:
+		switch {
+		case c.num < o.num:
+			return -1, true
+		case c.num > o.num:
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// parseCondExpr parses a condition expression.
+func parseCondExpr(s string) (*condExpr, error) {
+
+	p := &condParser{}
+
+	if err := p.tokenize(s); err != nil {
+		return nil, err
+	}
+
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected '%s'", p.tokens[p.pos].text)
+	}
+
+	return e, nil
+}
+
+type condTokenKind uint8
+
+const (
+	condTokIdent condTokenKind = iota
+	condTokString
+	condTokNumber
+	condTokOp
+)
+
+type condToken struct {
+	kind condTokenKind
+	text string
+}
+
+// condParser is a recursive descent parser for the grammar:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | primary
+//	primary    = "(" or ")" | comparison
+//	comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand | [ "not" ] "in" list ]
+//	list       = ( "[" | "(" ) { operand [ "," ] } ( "]" | ")" )
+//	operand    = field | 'string' | number | true | false | nil
+type condParser struct {
+	tokens []condToken
+	pos    int
+}
+
+func (p *condParser) tokenize(s string) error {
+
+	for i := 0; i < len(s); {
+
+		c := s[i]
+
+		switch {
+
+		case c == ' ' || c == '\t':
+			i++
+
+		case c == '\'' || c == '"':
+
+			end := strings.IndexByte(s[i+1:], c)
+			if end == -1 {
+				return errors.New("unterminated string")
+			}
+
+			p.tokens = append(p.tokens, condToken{kind: condTokString, text: s[i+1 : i+1+end]})
+			i += end + 2
+
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9':
+
+			j := i + 1
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+
+			p.tokens = append(p.tokens, condToken{kind: condTokNumber, text: s[i:j]})
+			i = j
+
+		case c == '_' || c == '^' || unicode.IsLetter(rune(c)):
+
+			// parent prefixes are only allowed at the start of a field
+			j := i + 1
+			for c == '^' && j < len(s) && s[j] == '^' {
+				j++
+			}
+			for j < len(s) && isCondIdentChar(s[j]) {
+				j++
+			}
+
+			p.tokens = append(p.tokens, condToken{kind: condTokIdent, text: s[i:j]})
+			i = j
+
+		default:
+
+			if i+1 < len(s) {
+				switch op := s[i : i+2]; op {
+				case "&&", "||", "==", "!=", "<=", ">=":
+					p.tokens = append(p.tokens, condToken{kind: condTokOp, text: op})
+					i += 2
+					continue
+				}
+			}
+
+			switch c {
+			case '!', '<', '>', '(', ')', '[', ']', ',':
+				p.tokens = append(p.tokens, condToken{kind: condTokOp, text: string(c)})
+				i++
+			default:
+				return fmt.Errorf("unexpected character '%c'", c)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isCondIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c == '[' || c == ']' || c >= '0' && c <= '9' || unicode.IsLetter(rune(c))
+}
+
+func (p *condParser) peek() (condToken, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return condToken{}, false
+}
+
+func (p *condParser) accept(kind condTokenKind, text string) bool {
+	if t, ok := p.peek(); ok && t.kind == kind && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *condParser) parseOr() (*condExpr, error) {
+
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(condTokOp, "||") {
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &condExpr{op: condOpOr, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *condParser) parseAnd() (*condExpr, error) {
+
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(condTokOp, "&&") {
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &condExpr{op: condOpAnd, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *condParser) parseUnary() (*condExpr, error) {
+
+	if p.accept(condTokOp, "!") {
+
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &condExpr{op: condOpNot, left: e}, nil
+	}
+
+	if p.accept(condTokOp, "(") {
+
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.accept(condTokOp, ")") {
+			return nil, errors.New("missing ')'")
+		}
+
+		return e, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *condParser) parseComparison() (*condExpr, error) {
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := p.peek()
+	if !ok {
+		return left, nil
+	}
+
+	var op condOp
+
+	switch {
+
+	case t.kind == condTokIdent && (t.text == "in" || t.text == "not"):
+
+		op = condOpIn
+		p.pos++
+
+		if t.text == "not" {
+			if !p.accept(condTokIdent, "in") {
+				return nil, errors.New("expected 'in' after 'not'")
+			}
+			op = condOpNotIn
+		}
+
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+
+		return &condExpr{op: op, left: left, list: list}, nil
+
+	case t.kind == condTokOp:
+
+		switch t.text {
+		case "==":
+			op = condOpEq
+		case "!=":
+			op = condOpNe
+		case "<":
+			op = condOpLt
+		case "<=":
+			op = condOpLte
+		case ">":
+			op = condOpGt
+		case ">=":
+			op = condOpGte
+		default:
+			return left, nil
+		}
+
+	default:
+		return left, nil
+	}
+
+	p.pos++
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return &condExpr{op: op, left: left, right: right}, nil
+}
+
+func (p *condParser) parseList() ([]*condExpr, error) {
+
+	var closing string
+
+	switch {
+	case p.accept(condTokOp, "["):
+		closing = "]"
+	case p.accept(condTokOp, "("):
+		closing = ")"
+	default:
+		return nil, errors.New("expected list after 'in'")
+	}
+
+	var list []*condExpr
+
+	for !p.accept(condTokOp, closing) {
+
+		if _, ok := p.peek(); !ok {
+			return nil, fmt.Errorf("missing '%s'", closing)
+		}
+
+		e, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, e)
+		p.accept(condTokOp, ",")
+	}
+
+	return list, nil
+}
+
+func (p *condParser) parseOperand() (*condExpr, error) {
+
+	t, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	p.pos++
+
+	switch t.kind {
+
+	case condTokString:
+		return &condExpr{op: condOpLiteral, lit: condValue{kind: condString, str: t.text}}, nil
+
+	case condTokNumber:
+
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", t.text)
+		}
+
+		return &condExpr{op: condOpLiteral, lit: condValue{kind: condNumber, num: f, raw: t.text}}, nil
+
+	case condTokIdent:
+
+		switch t.text {
+		case "true", "false":
+			return &condExpr{op: condOpLiteral, lit: condValue{kind: condBool, b: t.text == "true"}}, nil
+		case "nil", "null":
+			return &condExpr{op: condOpLiteral, lit: condValue{kind: condNil, isNil: true}}, nil
+		case "in", "not":
+			return nil, fmt.Errorf("unexpected '%s'", t.text)
+		}
+
+		return &condExpr{op: condOpField, field: t.text}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected '%s'", t.text)
+}
+
+// evalCondition evaluates the condition expression cached on the current tag.
+func evalCondition(fl FieldLevel) bool {
+	v := fl.(*validate)
+	return v.ct.expr.eval(v, v.slflParent)
+}
+
+// requiredWhen is the validation function
+// The field under validation must be present and not empty only when the expression is true.
+func requiredWhen(fl FieldLevel) bool {
+	if !evalCondition(fl) {
+		return true
+	}
+	return hasValue(fl)
+}
+
+// excludedWhen is the validation function
+// The field under validation must not be present or is empty when the expression is true.
+func excludedWhen(fl FieldLevel) bool {
+	if !evalCondition(fl) {
+		return true
+	}
+	return !hasValue(fl)
+}
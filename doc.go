@@ -47,6 +47,253 @@ Custom Validation functions can be added. Example:
 	// NOTES: using the same tag name as an existing function
 	//        will overwrite the existing one
 
+Validation functions explaining why they fail are registered using
+RegisterValidationErr, the error they return is kept on the FieldError and
+returned by its Cause and Unwrap methods, so it can be used by errors.As and
+by the TranslationFunc of the tag.
+
+	func password(ctx context.Context, fl validator.FieldLevel) error {
+
+		if len(fl.Field().String()) < 8 {
+			return &PasswordError{Code: "too_short", Min: 8}
+		}
+
+		return nil
+	}
+
+	validate.RegisterValidationErr("password", password)
+
+Validation Groups
+
+The same struct can be validated differently per use case by adding fields to
+groups using the 'groups' companion tag and validating with StructGroups, only
+the fields belonging to at least one of the groups passed in and fields not
+belonging to any group are validated. Struct ignores groups and validates all
+fields.
+
+	type User struct {
+		ID    int    `validate:"required" groups:"update"`
+		Name  string `validate:"required" groups:"create,update"`
+		Email string `validate:"required,email"`
+	}
+
+	err := validate.StructGroups(user, "create") // validates Name and Email
+
+Modifiers
+
+Fields can be sanitized before validation using the 'mod' companion tag, its
+comma separated modifiers are run in order by Modify, changing the fields of
+the struct pointer passed in, and StructModify modifies and then validates.
+Fields skipped by validation using '-' are also not modified.
+
+	type User struct {
+		Name  string `mod:"trim" validate:"required"`
+		Email string `mod:"trim,lcase" validate:"required,email"`
+		Age   int    `mod:"default=18" validate:"gte=18"`
+	}
+
+	err := validate.StructModify(&user)
+
+The baked in modifiers are trim, ltrim, rtrim, lcase, ucase, the nfc, nfd,
+nfkc and nfkd Unicode normalization forms, and default=value which sets
+strings, numbers, durations, bools and nil pointers to them when unset.
+Custom modifiers are registered using RegisterModifier.
+
+Validate Methods
+
+Types implementing either Validate() error or Validate(ctx context.Context) error
+have the method called during traversal, structs after their fields and struct
+level validations, and other types such as slices, maps and dived elements once
+their tags pass. Pointer receiver methods are only called for values that can
+be addressed, eg. when passing a pointer to Struct.
+
+The method of the value passed to Struct, Var or their variants is not called,
+only those of the values it contains. The fields of a struct being validated
+before its method is called, the method holds the checks its tags cannot
+express rather than validating its own value again.
+
+ValidationErrors returned are added with their namespaces relative to the
+value, without the name of their top level struct and dropping those already
+reported for the value, any other error is added as a FieldError of the value
+with the 'validatemethod' tag and the error's message as its param.
+
+	func (r *Range) Validate() error {
+		if r.Min > r.Max {
+			return errors.New("min is greater than max")
+		}
+		return nil
+	}
+
+Fields opt out of the call using the 'novalidatemethod' tag.
+
+	Usage: novalidatemethod
+
+Other methods are called using the 'method' tag, looked up on the struct
+containing the field, then on the field's type or the type of its elements. The
+method must have one of the signatures func() bool, func() error or
+func(context.Context) error, the field failing when it returns false or an error.
+Methods are looked up once when the struct is cached, panicking if they don't
+exist or have the wrong signature.
+
+	type Order struct {
+		Kind string
+		SKU  string `validate:"method=CheckSKU"`
+	}
+
+	func (o Order) CheckSKU() error {
+		if o.Kind == "digital" && !strings.HasPrefix(o.SKU, "D") {
+			return errors.New("digital SKUs start with D")
+		}
+		return nil
+	}
+
+	Usage: method=CheckSKU
+
+Validating Maps
+
+JSON-like data that is never decoded into structs is validated using Map, with
+rules keyed by the keys of the data. A rule is the tags of the value, the rules
+of a nested object, applied to each object of an array, or a slice of rules
+applied in turn. Keys containing '*' are wildcards matching the other keys of
+the data, and in strict mode keys without any rule are reported using the
+'unknown_key' tag, see SetStrictMaps and WithStrictMaps.
+
+	err := validate.Map(data, map[string]interface{}{
+		"name":   "required",
+		"emails": "required,dive,email",
+		"addresses": []interface{}{
+			"required",
+			map[string]interface{}{"zip": "required,len=5"},
+		},
+		"attr_*": "max=64",
+	})
+
+The errors are namespaced by their path within the data eg. 'addresses[2].zip'.
+
+Raw JSON documents are validated using ValidateJSON and the same rules, reading
+the document token by token instead of decoding it into maps. Its numbers are not
+rounded, those a float64 can't hold exactly being kept as json.Number. Its errors
+implement JSONFieldError, locating them using a JSON Pointer and a byte offset.
+
+	err := validate.ValidateJSON(body, rules)
+
+	for _, fe := range err.(validator.ValidationErrors) {
+		jfe := fe.(validator.JSONFieldError)
+		fmt.Println(jfe.JSONPointer(), jfe.Offset()) // eg. /addresses/2/zip 184
+	}
+
+Struct Rules
+
+Types that cannot be tagged, eg. generated or third party types, can have their
+fields' rules registered instead, keyed by the fields' names. RegisterStructRules
+replaces the fields' tags and MergeStructRules appends to them. Rules can also be
+loaded from a JSON document using RegisterRulesJSON, or any other format using
+RegisterRulesDocument and the format's unmarshal function, mapping the types'
+names to their fields' rules. Registering rules for a type already validated
+replaces its cached tags.
+
+	validate.RegisterStructRules(pb.User{}, map[string]string{
+		"Email": "required,email",
+		"Name":  "required,max=64",
+	})
+
+	err := validate.RegisterRulesJSON(data, pb.User{}, pb.Address{})
+
+Rules must be registered before the type is first validated.
+
+Rule Builder
+
+Instead of tag strings, the rules of a struct's fields can be built using the
+typed constructors of the rules package, so a misspelled rule name fails to
+compile. The rules are parsed into the same validations as their tags, custom
+validations and aliases being used with rules.Tag once registered.
+
+	validate.For(&User{}).
+		Field("Name", rules.Required(), rules.Min(3)).
+		Field("Emails", rules.Dive(rules.Email())).
+		Field("Code", rules.Tag("sku")).
+		StructLevel(UserStructLevelValidation)
+
+Warnings
+
+Tags within 'warn' are soft rules whose failures are warnings, eg. deprecated
+fields or weak but acceptable passwords, that do not fail the validation. They
+are returned by StructResult and VarResult along with the errors, as FieldErrors
+whose Severity is SeverityWarning, and are otherwise ignored.
+
+	Password string `validate:"required,warn(min=12,containsany=!@#),max=64"`
+
+	res := validate.StructResult(user)
+	if err := res.Err(); err != nil {
+		return err
+	}
+	for _, w := range res.Warnings {
+		log.Println(w.Translate(trans))
+	}
+
+Warnings are translated using the translation registered for the tag within
+'warn', eg. 'warn(min)', falling back to that of the tag.
+
+	Usage: warn(min=12)
+
+Custom Messages
+
+Fields can have their own messages and error codes using the 'msg' and 'errcode'
+companion tags, or 'msg_<tag>' and 'errcode_<tag>' for a single validation, which
+are returned by FieldError's Message and Code methods. The message overrides any
+translation of the error, its {field}, {value}, {param} and {tag} placeholders
+being replaced by those of the error.
+
+	type User struct {
+		Name string `validate:"required,min=3" msg:"{field} is required" msg_min:"{field} needs {param} characters" errcode_min:"NAME_SHORT"`
+	}
+
+Nullable Values
+
+Values implementing driver.Valuer eg. sql.NullString are validated using the value
+they return, as are those implementing Optional. A null value behaves like a nil
+pointer, failing 'required' and skipping the tags after 'omitempty'. Valuer slices,
+arrays, maps and structs having validations of their own, such as those stored as
+JSON, are validated as they are. Types registered using RegisterCustomTypeFunc are
+left to their CustomTypeFunc.
+
+	type User struct {
+		Nick sql.NullString `validate:"omitempty,min=3"`
+		Age  sql.NullInt64  `validate:"required,gte=18"`
+	}
+
+Limiting Errors
+
+By default all errors are collected, validation can instead be stopped after
+the first error or after a number of errors, either for every validation or
+per call using the context.
+
+	validate.SetMaxErrors(10) // or validate.SetFailFast(true)
+
+	err := validate.StructCtx(validator.WithFailFast(ctx), req)
+
+Serializing Errors
+
+ValidationErrors and FieldError encode to a stable JSON document, and can be
+wrapped in an RFC 7807 'application/problem+json' body with the errors under
+the 'errors' extension member.
+
+	if errs, ok := err.(validator.ValidationErrors); ok {
+		_ = errs.WriteProblem(w, trans, false)
+		return
+	}
+
+Grouping Errors
+
+ValidationErrors are in the order of validation, they can be sorted by namespace,
+grouped using ByField, narrowed to a field and its children using Filter and
+combined using Merge. Tree nests them like the document validated, which encodes
+to JSON using their tags, the elements of slices and arrays as arrays and the
+values of maps as objects, and FieldError's JSONPointer locates a field within it.
+
+	errs.Sort()
+	b, _ := json.Marshal(errs.Filter("User.addresses").Tree()) // {"addresses":[null,{"zip":["required"]}]}
+
 Cross-Field Validation
 
 Cross-Field Validation can be done via the following tags:
@@ -101,6 +348,31 @@ used "eqcsfield" it could be multiple levels down. Example:
 	//       whatever you pass, struct, field...
 	//       when calling validate.Field(field, tag) val will be nil
 
+Param References
+
+The param of any tag may reference a field of the struct containing the field,
+written '${Namespace}', or a key of the param source of the validation, written
+'@{key}', in place of a literal. Params only starting by '$' or '@' eg.
+'endswith=@example.com' remain literals. The reference is resolved each time the field is validated
+and the FieldError reports the resolved param. Slices and arrays are resolved to
+their elements separated by spaces eg. for 'oneof'.
+
+	type Order struct {
+		MaxItems int
+		Allowed  []string
+		Items    []string `validate:"max=${MaxItems},dive,oneof=${Allowed}"`
+		Name     string   `validate:"max=@{limits.name_len}"`
+	}
+
+	ctx := validator.WithParamSource(ctx, validator.ParamMap(map[string]interface{}{
+		"limits": tenant.Limits,
+	}))
+
+	err := validate.StructCtx(ctx, order)
+
+A reference that can't be resolved, eg. a nil struct along the namespace or a key
+the param source doesn't have, fails the validation.
+
 Multiple Validators
 
 Multiple validators on a field will process in the order defined. Example:
@@ -167,6 +439,21 @@ colors to be accepted. This can also be combined with 'and' for example
 
 	Usage: |
 
+Grouping and Negation
+
+Parentheses group validations, within which ',' is the 'and' operator and '|' the
+'or' operator binding tighter as outside of them, and '!' negates the validation
+or group following it. Groups can't contain dive, keys, omitempty or the other
+tags changing how the field is traversed.
+
+	Usage: (email|e164),!contains=test,(len=0|min=8)
+	Usage: startswith=A|(len=3,numeric)
+
+The FieldError of a group reports the part of it that failed: the validation
+failing within an 'and', the whole 'or' eg. 'email|e164' and the negation eg.
+'!contains', with the param of the validation it negates, so that translations
+and messages of the validations within groups keep working.
+
 StructOnly
 
 When a field that is a nested struct is encountered, and contains this flag
@@ -282,6 +569,81 @@ Examples:
 	// require the field unless the Field1 and Field2 is equal to the value respectively:
 	Usage: required_unless=Field1 foo Field2 bar
 
+Excluded If
+
+The field under validation must not be present or is empty only if all
+the other specified fields are equal to the value following the specified
+field.
+
+	Usage: excluded_if
+
+Examples:
+
+	// exclude the field if the Field1 is equal to the parameter given:
+	Usage: excluded_if=Field1 foobar
+
+	// exclude the field if the Field1 and Field2 is equal to the value respectively:
+	Usage: excluded_if=Field1 foo Field2 bar
+
+Excluded Unless
+
+The field under validation must not be present or is empty unless all
+the other specified fields are equal to the value following the specified
+field.
+
+	Usage: excluded_unless
+
+Examples:
+
+	// exclude the field unless the Field1 is equal to the parameter given:
+	Usage: excluded_unless=Field1 foobar
+
+	// exclude the field unless the Field1 and Field2 is equal to the value respectively:
+	Usage: excluded_unless=Field1 foo Field2 bar
+
+Conditional Field Paths
+
+The fields referenced by the required_* and excluded_* tags, and within the
+required_when and excluded_when expressions, are relative to the struct
+containing the field under validation and may be a dotted path into nested
+structs eg. Inner.Field or Items[0].Field. Each leading ^ moves up to the
+enclosing struct, ^Field being a field of the parent struct and ^^Field one of
+the grandparent struct; a path leading above the top level struct is not found.
+
+	Usage: required_if=^Kind paid
+	Usage: excluded_unless=^^Inner.Kind draft
+
+Required When
+
+The field under validation must be present and not empty only when the
+boolean expression is true. Expressions are parsed once when the tag is
+cached, invalid expressions panic at that time.
+
+Expressions support field references, which can be nested namespaces eg.
+Inner.Field or Items[0], 'string', number, true, false and nil literals, the
+==, !=, <, <=, >, >= comparisons, in and not in lists, the &&, || and !
+operators and parentheses. A field on its own is true when it has a value,
+slices, arrays and maps are compared using their length.
+
+NOTE: a comma within the expression must use the UTF-8 hex representation 0x2C.
+
+	Usage: required_when=Status=='active' && (Age>=18 || Guardian!='')
+
+Examples:
+
+	// require the field when Status is one of the values:
+	Usage: required_when=Status in ('active' 'pending')
+
+	// require the field unless Inner is nil or Inner.Kind is 'a':
+	Usage: required_when=Inner!=nil && Inner.Kind!='a'
+
+Excluded When
+
+The field under validation must not be present or is empty when the boolean
+expression is true, see Required When for the expression syntax.
+
+	Usage: excluded_when=Status=='closed'
+
 Required With
 
 The field under validation must be present and not empty only if any
@@ -1258,6 +1620,82 @@ More information on https://golang.org/pkg/time/#LoadLocation
 
 	Usage: timezone
 
+Before and After
+
+This validates that a time.Time, or an RFC 3339 string, is before or after the time
+of the param, either an RFC 3339 time, a date or a time relative to the current time
+using a duration.
+
+	Usage: after=now-24h,before=2024-04-01
+
+Future and Past
+
+This validates that a time.Time, or an RFC 3339 string, is in the future or in the past.
+
+	Usage: future
+
+Within
+
+This validates that a time.Time, or an RFC 3339 string, is within the duration of the
+current time, in the past or in the future.
+
+	Usage: within=72h
+
+Weekday
+
+This validates that a time.Time, or an RFC 3339 string, falls on one of the days, in
+its own location, separated by spaces or ranges of days which may wrap around the
+end of the week.
+
+	Usage: weekday=mon-fri
+	Usage: weekday=sat sun
+
+Date Only
+
+This validates that a time.Time is at midnight, or that a string is a date of the
+2006-01-02 format.
+
+	Usage: date_only
+
+Timezone Is
+
+This validates that a time.Time, or an RFC 3339 string, is in the time zone, its
+location being the zone or its offset being that of the zone at that time.
+
+	Usage: timezone_is=Europe/Paris
+
+The current time of every time based validation, including those of gt, gte, lt and
+lte on time.Time, is that of the clock set using SetClock or WithClock, falling back
+to time.Now, so that they can be tested deterministically.
+
+	validate.SetClock(func() time.Time { return time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC) })
+
+Exact Numbers
+
+The following validate numbers exactly, without rounding them to a float64 or an
+int64, whether they are integers, floats, numeric strings eg. json.Number or numbers
+of math/big ie. big.Int, big.Float and big.Rat. Floats are taken as the shortest
+decimal representing them eg. 0.1, and strings that are not decimal numbers fail.
+
+Min, Max, Greater Than and Less Than Number
+
+	Usage: min_num=0.01,max_num=99999999.99
+	Usage: gt_num=0,lt_num=18446744073709551616
+
+Multiple Of
+
+This validates that the number is a multiple of the param.
+
+	Usage: multiple_of=0.05
+
+Decimal
+
+This validates that the number fits a decimal of the precision and scale, the
+total number of digits and the number of digits after the decimal point, like
+SQL's DECIMAL(10,2). They are separated by a space as commas separate tags.
+
+	Usage: decimal=10 2
+
 
 Alias Validators and Tags
 
@@ -1273,6 +1711,17 @@ Here is a list of the current built in alias tags:
 	"country_code"
 		alias is "iso3166_1_alpha2|iso3166_1_alpha3|iso3166_1_alpha_numeric" (Usage: country_code)
 
+Aliases may take positional params, $1 being replaced by the first param of the
+alias, $2 by the second and so on, the params being separated by spaces and
+quoted by single quotes like those of 'oneof'. The number of params must match
+the greatest positional param of the alias, otherwise it will panic.
+
+	validate.RegisterAlias("range", "min=$1,max=$2")
+	validate.RegisterAlias("percent", "range=0 100")
+
+	Usage: range=1 10
+	Usage: percent
+
 Validator notes:
 
 	regex
@@ -1319,6 +1768,24 @@ Here is a list of the current non standard validators:
 
 		Usage: notblank
 
+JSON Schema
+
+A Draft 2020-12 JSON Schema can be generated from the validation tags of a
+struct, using the same cached information used during validation. Tags that
+cannot be expressed, eg. cross field validations, are listed per property
+using the 'x-unsupported-tags' keyword, and tags within 'warn' are left out as
+they don't reject values. The properties of 'omitempty' fields are the 'anyOf'
+their zero value and the schema of their validations.
+
+	schema, err := validate.JSONSchema(&User{})
+	b, err := json.Marshal(schema)
+
+OpenAPI 3.1 'components.schemas' entries can be generated the same way for a
+number of types, nested struct types are added as their own components.
+
+	components, err := validate.OpenAPIComponents(CreateUserRequest{}, UpdateUserRequest{})
+	b, err := components.YAML()
+
 Panics
 
 This package panics when bad input is provided, this is by design, bad code like
@@ -1333,5 +1800,14 @@ that should not make it to production.
 	}
 
 	validate.Struct(t) // this will panic
+
+To find these problems at startup instead, Check parses and caches the tags of
+the structs passed in and of all the structs they reference, returning every
+problem found as TagErrors eg. undefined validations, cross field tags naming
+fields that do not exist or tags used on kinds they cannot validate.
+
+	if err := validate.Check(&User{}, &Order{}); err != nil {
+		log.Fatal(err)
+	}
 */
 package validator
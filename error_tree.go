@@ -0,0 +1,414 @@
+package validator
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const treeErrorsKey = "_errors"
+
+// ErrorTree is a tree of ValidationErrors grouped by the segments of their namespace,
+// nesting them like the document validated, see ValidationErrors.Tree.
+type ErrorTree struct {
+	// Errors are the errors of the value itself.
+	Errors ValidationErrors
+
+	// Children are the subtrees of the value's fields, map keys and indexes, keyed
+	// by field name, map key or index eg. 'addresses', 'home' and '2'.
+	Children map[string]*ErrorTree
+
+	// indexed is whether the children are the elements of a slice or array, as
+	// opposed to the values of a map keyed by integers.
+	indexed bool
+}
+
+// nsSegment is a segment of a namespace, a field name or the contents of the
+// brackets of a map key or index.
+type nsSegment struct {
+	key     string
+	bracket bool
+	offset  int // offset within the namespace of the segment, of its '[' for brackets
+}
+
+// Tree returns the tree of the errors grouped by the segments of their namespace,
+// without the top level struct's name, eg. the error of the namespace
+// 'User.addresses[2].zip' is added to the tree at 'addresses' > '2' > 'zip'.
+func (ve ValidationErrors) Tree() *ErrorTree {
+
+	root := new(ErrorTree)
+
+	for _, fe := range ve {
+
+		node := root
+
+		for _, seg := range parseNamespace(relativeNamespace(fe)) {
+
+			if node.Children == nil {
+				node.Children = make(map[string]*ErrorTree)
+				node.indexed = true
+			}
+
+			node.indexed = node.indexed && seg.bracket && isSliceIndex(fe, seg.offset)
+
+			child, ok := node.Children[seg.key]
+			if !ok {
+				child = new(ErrorTree)
+				node.Children[seg.key] = child
+			}
+			node = child
+		}
+
+		node.Errors = append(node.Errors, fe)
+	}
+
+	return root
+}
+
+// Value returns the tree as JSON-like values, eg. for encoding/json, rendering the
+// errors of a value using fn:
+//
+//	[]interface{}          the errors of a value without children
+//	map[string]interface{} the children of a value keyed by field name or map key, along
+//	                       with its errors under the '_errors' key, if any
+//	[]interface{}          the children of a value which are the elements of a slice or
+//	                       array, gaps being nil
+//
+// eg. {"user":{"addresses":[nil,nil,{"zip":["required"]}]}}
+func (t *ErrorTree) Value(fn func(fe FieldError) interface{}) interface{} {
+
+	if len(t.Children) == 0 {
+		return t.errorValues(fn)
+	}
+
+	if len(t.Errors) == 0 && t.indexed {
+		if max, ok := t.maxIndex(); ok {
+
+			arr := make([]interface{}, max+1)
+
+			for key, child := range t.Children {
+				idx, _ := strconv.Atoi(key)
+				arr[idx] = child.Value(fn)
+			}
+
+			return arr
+		}
+	}
+
+	m := make(map[string]interface{}, len(t.Children)+1)
+
+	for key, child := range t.Children {
+		m[key] = child.Value(fn)
+	}
+
+	if len(t.Errors) > 0 {
+		m[treeErrorsKey] = t.errorValues(fn)
+	}
+
+	return m
+}
+
+// MarshalJSON renders the tree using the tags of the errors, see Value.
+func (t *ErrorTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Value(func(fe FieldError) interface{} {
+		return fe.Tag()
+	}))
+}
+
+// errorValues renders the errors of the value itself using fn.
+func (t *ErrorTree) errorValues(fn func(fe FieldError) interface{}) []interface{} {
+
+	values := make([]interface{}, len(t.Errors))
+
+	for i, fe := range t.Errors {
+		values[i] = fn(fe)
+	}
+
+	return values
+}
+
+// maxIndex returns the greatest index of the children when all of them are keyed
+// by an index.
+func (t *ErrorTree) maxIndex() (int, bool) {
+
+	max := -1
+
+	for key := range t.Children {
+
+		if !isIndex(key) {
+			return 0, false
+		}
+
+		idx, err := strconv.Atoi(key)
+		if err != nil {
+			return 0, false
+		}
+
+		if idx > max {
+			max = idx
+		}
+	}
+
+	return max, true
+}
+
+// ByField returns the errors grouped by namespace.
+func (ve ValidationErrors) ByField() map[string]ValidationErrors {
+
+	m := make(map[string]ValidationErrors)
+
+	for _, fe := range ve {
+		m[fe.Namespace()] = append(m[fe.Namespace()], fe)
+	}
+
+	return m
+}
+
+// Filter returns the errors of the value at the namespace prefix and of all of its
+// fields, map keys and indexes eg. the prefix 'User.addresses' matches
+// 'User.addresses', 'User.addresses[2].zip' but not 'User.addressesCount'.
+func (ve ValidationErrors) Filter(prefix string) ValidationErrors {
+
+	var errs ValidationErrors
+
+	for _, fe := range ve {
+
+		ns := fe.Namespace()
+
+		if !strings.HasPrefix(ns, prefix) {
+			continue
+		}
+
+		if len(ns) == len(prefix) || len(prefix) == 0 ||
+			ns[len(prefix)] == '.' || ns[len(prefix)] == '[' ||
+			prefix[len(prefix)-1] == '.' {
+			errs = append(errs, fe)
+		}
+	}
+
+	return errs
+}
+
+// Merge returns the errors followed by those of others, eg. returned by separate
+// validations, omitting the errors of the same namespace and tag as a previous one.
+func (ve ValidationErrors) Merge(others ...ValidationErrors) ValidationErrors {
+
+	errs := make(ValidationErrors, 0, len(ve))
+	seen := make(map[[2]string]struct{})
+
+	add := func(list ValidationErrors) {
+		for _, fe := range list {
+
+			key := [2]string{fe.Namespace(), fe.Tag()}
+
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			errs = append(errs, fe)
+		}
+	}
+
+	add(ve)
+
+	for _, o := range others {
+		add(o)
+	}
+
+	return errs
+}
+
+// Sort sorts the errors by namespace, segment by segment with indexes compared as
+// numbers eg. 'items[2]' before 'items[10]', keeping the errors of the same
+// namespace in order.
+func (ve ValidationErrors) Sort() {
+
+	segs := make(map[string][]nsSegment, len(ve))
+
+	for _, fe := range ve {
+		if _, ok := segs[fe.Namespace()]; !ok {
+			segs[fe.Namespace()] = parseNamespace(fe.Namespace())
+		}
+	}
+
+	sort.SliceStable(ve, func(i, j int) bool {
+		return compareSegments(segs[ve[i].Namespace()], segs[ve[j].Namespace()]) < 0
+	})
+}
+
+// compareSegments compares the segments of two namespaces.
+func compareSegments(a []nsSegment, b []nsSegment) int {
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+
+		if a[i].bracket && b[i].bracket && isIndex(a[i].key) && isIndex(b[i].key) &&
+			len(a[i].key) != len(b[i].key) {
+
+			if len(a[i].key) < len(b[i].key) {
+				return -1
+			}
+			return 1
+		}
+
+		if a[i].key != b[i].key {
+			if a[i].key < b[i].key {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return len(a) - len(b)
+}
+
+// relativeNamespace returns the namespace of the error without the top level
+// struct's name.
+func relativeNamespace(fe FieldError) string {
+
+	if e, ok := fe.(*fieldError); ok {
+		return e.ns[e.rootLen:]
+	}
+
+	if e, ok := fe.(*jsonFieldError); ok {
+		return e.ns[e.rootLen:]
+	}
+
+	return fe.Namespace()
+}
+
+// parseNamespace splits the namespace into its segments eg. 'addresses[2].zip' into
+// 'addresses', '2' and 'zip'.
+func parseNamespace(ns string) []nsSegment {
+
+	var segs []nsSegment
+
+	size := len(ns)
+
+	for len(ns) > 0 {
+
+		offset := size - len(ns)
+
+		switch ns[0] {
+
+		case '[':
+			end := strings.Index(ns, rightBracket)
+			if end < 0 {
+				end = len(ns)
+				ns += rightBracket
+			}
+			segs = append(segs, nsSegment{key: ns[1:end], bracket: true, offset: offset})
+			ns = ns[end+1:]
+
+		case '.':
+			ns = ns[1:]
+
+		default:
+			end := strings.IndexAny(ns, namespaceSeparator+leftBracket)
+			if end < 0 {
+				end = len(ns)
+			}
+			segs = append(segs, nsSegment{key: ns[:end], offset: offset})
+			ns = ns[end:]
+		}
+	}
+
+	return segs
+}
+
+// isSliceIndex reports whether the '[' at the offset within the namespace of the error,
+// without the top level struct's name, opens the index of a slice or array element.
+func isSliceIndex(fe FieldError, offset int) bool {
+
+	var e *fieldError
+
+	switch t := fe.(type) {
+	case *fieldError:
+		e = t
+	case *jsonFieldError:
+		e = t.fieldError
+	default:
+		return false
+	}
+
+	for _, idx := range e.indexes {
+		if idx == int(e.rootLen)+offset {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isIndex reports whether the key is an index, made of digits without leading zeros.
+func isIndex(key string) bool {
+
+	if len(key) == 0 || (key[0] == '0' && len(key) > 1) {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < '0' || key[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setRootLen records the length of the top level struct's name prefixing the
+// namespace of the errors.
+func setRootLen(errs ValidationErrors, rootLen int) {
+
+	if rootLen > math.MaxUint8 {
+		return
+	}
+
+	for _, fe := range errs {
+		switch e := fe.(type) {
+		case *fieldError:
+			e.rootLen = uint8(rootLen)
+		case *jsonFieldError:
+			e.rootLen = uint8(rootLen)
+		}
+	}
+}
+
+// setIndex records the offset within the namespace of the errors of the '[' of the
+// index of the slice or array element they belong to.
+func setIndex(errs ValidationErrors, offset int) {
+
+	for _, fe := range errs {
+		switch e := fe.(type) {
+		case *fieldError:
+			e.indexes = append(e.indexes, offset)
+		case *jsonFieldError:
+			e.indexes = append(e.indexes, offset)
+		}
+	}
+}
+
+// shiftIndexes returns a copy of the offsets of the indexes of a namespace shifted by
+// n, eg. once prefixed.
+func shiftIndexes(indexes []int, n int) []int {
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	shifted := make([]int, len(indexes))
+
+	for i, idx := range indexes {
+		shifted[i] = idx + n
+	}
+
+	return shifted
+}
+
+// JSONPointer returns the RFC 6901 JSON Pointer of the field built from its namespace,
+// without the top level struct's name.
+func (fe *fieldError) JSONPointer() string {
+	return namespaceToJSONPointer(fe.ns[fe.rootLen:])
+}
@@ -2,8 +2,10 @@ package validator
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"reflect"
 	"strings"
 
@@ -11,7 +13,14 @@ import (
 )
 
 const (
-	fieldErrMsg = "Key: '%s' Error:Field validation for '%s' failed on the '%s' tag"
+	fieldErrMsg  = "Key: '%s' Error:Field validation for '%s' failed on the '%s' tag"
+	fieldWarnMsg = "Key: '%s' Warning:Field validation for '%s' failed on the '%s' tag"
+
+	// ProblemContentType is the media type of RFC 7807 problem details documents.
+	ProblemContentType = "application/problem+json"
+
+	problemTitle  = "Validation Failed"
+	problemDetail = "One or more fields failed validation."
 )
 
 // ValidationErrorsTranslations is the translation return type
@@ -45,12 +54,10 @@ func (ve ValidationErrors) Error() string {
 
 	buff := bytes.NewBufferString("")
 
-	var fe *fieldError
-
 	for i := 0; i < len(ve); i++ {
 
-		fe = ve[i].(*fieldError)
-		buff.WriteString(fe.Error())
+		// errors may be wrapping fieldErrors eg. those of ValidateJSON
+		buff.WriteString(ve[i].Error())
 		buff.WriteString("\n")
 	}
 
@@ -65,7 +72,13 @@ func (ve ValidationErrors) Translate(ut ut.Translator) ValidationErrorsTranslati
 	var fe *fieldError
 
 	for i := 0; i < len(ve); i++ {
-		fe = ve[i].(*fieldError)
+
+		switch e := ve[i].(type) {
+		case *jsonFieldError:
+			fe = e.fieldError
+		default:
+			fe = e.(*fieldError)
+		}
 
 		// // in case an Anonymous struct was used, ensure that the key
 		// // would be 'Username' instead of ".Username"
@@ -80,6 +93,141 @@ func (ve ValidationErrors) Translate(ut ut.Translator) ValidationErrorsTranslati
 	return trans
 }
 
+// MarshalJSON implements json.Marshaler, the errors are encoded as an array
+// of FieldErrorDocument without values and with untranslated messages.
+func (ve ValidationErrors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ve.Documents(nil, false))
+}
+
+// Documents returns the JSON documents of all of the ValidationErrors, see
+// NewFieldErrorDocument.
+func (ve ValidationErrors) Documents(trans ut.Translator, includeValue bool) []FieldErrorDocument {
+
+	docs := make([]FieldErrorDocument, len(ve))
+
+	for i := 0; i < len(ve); i++ {
+		docs[i] = NewFieldErrorDocument(ve[i], trans, includeValue)
+	}
+
+	return docs
+}
+
+// Problem wraps the ValidationErrors in an RFC 7807 problem details document
+// with the errors added as the 'errors' extension member.
+//
+// NOTE: the status defaults to 422 Unprocessable Entity, it and the other
+// members may be changed before encoding.
+func (ve ValidationErrors) Problem(trans ut.Translator, includeValue bool) *ProblemDetails {
+	return &ProblemDetails{
+		Title:  problemTitle,
+		Status: http.StatusUnprocessableEntity,
+		Detail: problemDetail,
+		Errors: ve.Documents(trans, includeValue),
+	}
+}
+
+// WriteProblem writes the ValidationErrors to w as an 'application/problem+json'
+// response body, see Problem.
+func (ve ValidationErrors) WriteProblem(w http.ResponseWriter, trans ut.Translator, includeValue bool) error {
+	return ve.Problem(trans, includeValue).Write(w)
+}
+
+// FieldErrorDocument is the stable JSON representation of a FieldError.
+type FieldErrorDocument struct {
+	Namespace       string      `json:"namespace"`
+	StructNamespace string      `json:"structNamespace"`
+	Field           string      `json:"field"`
+	StructField     string      `json:"structField"`
+	Tag             string      `json:"tag"`
+	ActualTag       string      `json:"actualTag"`
+	Param           string      `json:"param"`
+	Kind            string      `json:"kind"`
+	Type            string      `json:"type"`
+	Value           interface{} `json:"value,omitempty"`
+	Severity        string      `json:"severity,omitempty"`
+	Code            string      `json:"code,omitempty"`
+	Cause           string      `json:"cause,omitempty"`
+	Message         string      `json:"message"`
+}
+
+// NewFieldErrorDocument returns the JSON document of fe, the message is
+// translated using trans when not nil.
+//
+// NOTE: values are only included when includeValue is true as they may
+// contain sensitive information, values that cannot be encoded as JSON are
+// included using their default string format.
+func NewFieldErrorDocument(fe FieldError, trans ut.Translator, includeValue bool) FieldErrorDocument {
+
+	doc := FieldErrorDocument{
+		Namespace:       fe.Namespace(),
+		StructNamespace: fe.StructNamespace(),
+		Field:           fe.Field(),
+		StructField:     fe.StructField(),
+		Tag:             fe.Tag(),
+		ActualTag:       fe.ActualTag(),
+		Param:           fe.Param(),
+		Kind:            fe.Kind().String(),
+	}
+
+	if typ := fe.Type(); typ != nil {
+		doc.Type = typ.String()
+	}
+
+	if fe.Severity() == SeverityWarning {
+		doc.Severity = fe.Severity().String()
+	}
+
+	doc.Code = fe.Code()
+
+	if cause := fe.Cause(); cause != nil {
+		doc.Cause = cause.Error()
+	}
+
+	if includeValue {
+		doc.Value = fe.Value()
+		if _, err := json.Marshal(doc.Value); err != nil {
+			doc.Value = fmt.Sprintf("%v", doc.Value)
+		}
+	}
+
+	if trans != nil {
+		doc.Message = fe.Translate(trans)
+	} else if msg := fe.Message(); len(msg) > 0 {
+		doc.Message = msg
+	} else {
+		doc.Message = fe.Error()
+	}
+
+	return doc
+}
+
+// ProblemDetails is an RFC 7807 problem details document carrying the
+// validation errors in the 'errors' extension member.
+type ProblemDetails struct {
+	Type     string               `json:"type,omitempty"`
+	Title    string               `json:"title"`
+	Status   int                  `json:"status"`
+	Detail   string               `json:"detail,omitempty"`
+	Instance string               `json:"instance,omitempty"`
+	Errors   []FieldErrorDocument `json:"errors"`
+}
+
+// Write writes the problem details to w with the 'application/problem+json'
+// content type and the problem's status code.
+func (p *ProblemDetails) Write(w http.ResponseWriter) error {
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+
+	_, err = w.Write(b)
+	return err
+}
+
 // FieldError contains all functions to get error details
 type FieldError interface {
 
@@ -140,6 +288,14 @@ type FieldError interface {
 	// help with generating an error message
 	Param() string
 
+	// Cause returns the error explaining the failure returned by the validation,
+	// registered using RegisterValidationErr, or by the method of the 'method'
+	// tag, or nil when there is none. It is also returned by Unwrap.
+	//
+	// eg. the error of a validation returning a structured error holding a code
+	// and params used by its TranslationFunc
+	Cause() error
+
 	// Kind returns the Field's reflect Kind
 	//
 	// eg. time.Time's kind is a struct
@@ -157,6 +313,28 @@ type FieldError interface {
 	// calling fe.Error()
 	Translate(ut ut.Translator) string
 
+	// Severity returns the severity of the error, SeverityWarning for the failures
+	// of tags within 'warn' which do not fail the validation.
+	Severity() Severity
+
+	// Message returns the field's custom message set using the 'msg' or
+	// 'msg_<tag>' companion tags, with its placeholders replaced, or an
+	// empty string when there is none.
+	//
+	// eg. `msg_min:"{field} must have at least {param} characters"`
+	Message() string
+
+	// Code returns the field's error code set using the 'errcode' or
+	// 'errcode_<tag>' companion tags, or an empty string when there is none.
+	Code() string
+
+	// JSONPointer returns the RFC 6901 JSON Pointer of the field built from its
+	// namespace, without the top level struct's name, so it locates the field in
+	// the struct's JSON document when using its JSON names as namespace.
+	//
+	// eg. JSON Pointer of the namespace 'User.addresses[2].zip' is '/addresses/2/zip'
+	JSONPointer() string
+
 	// Error returns the FieldError's message
 	Error() string
 }
@@ -180,6 +358,11 @@ type fieldError struct {
 	param          string
 	kind           reflect.Kind
 	typ            reflect.Type
+	severity       Severity
+	messages       *cMessages
+	rootLen        uint8 // length of the top level struct's name prefixing the namespace
+	indexes        []int // offsets within ns of the '[' of the indexes of slice and array elements
+	cause          error // only populated for validations returning an error eg. FuncErr
 }
 
 // Tag returns the validation tag that failed.
@@ -240,6 +423,17 @@ func (fe *fieldError) Param() string {
 	return fe.param
 }
 
+// Cause returns the error returned by the validation that failed, if any.
+func (fe *fieldError) Cause() error {
+	return fe.cause
+}
+
+// Unwrap returns the error returned by the validation that failed, if any,
+// for use with errors.Is and errors.As.
+func (fe *fieldError) Unwrap() error {
+	return fe.cause
+}
+
 // Kind returns the Field's reflect Kind
 func (fe *fieldError) Kind() reflect.Kind {
 	return fe.kind
@@ -250,23 +444,50 @@ func (fe *fieldError) Type() reflect.Type {
 	return fe.typ
 }
 
+// MarshalJSON implements json.Marshaler, see NewFieldErrorDocument.
+func (fe *fieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(NewFieldErrorDocument(fe, nil, false))
+}
+
+// Severity returns the severity of the error.
+func (fe *fieldError) Severity() Severity {
+	return fe.severity
+}
+
 // Error returns the fieldError's error message
 func (fe *fieldError) Error() string {
+	if fe.severity == SeverityWarning {
+		return fmt.Sprintf(fieldWarnMsg, fe.ns, fe.Field(), fe.tag)
+	}
 	return fmt.Sprintf(fieldErrMsg, fe.ns, fe.Field(), fe.tag)
 }
 
 // Translate returns the FieldError's translated error
 // from the provided 'ut.Translator' and registered 'TranslationFunc'
 //
+// The field's custom message, see Message, overrides any translation. Warnings
+// are translated using the translation registered for the tag within 'warn'
+// eg. 'warn(min)' when there is one, and that of the tag otherwise.
+//
 // NOTE: if no registered translation can be found, it returns the original
 // untranslated error message.
 func (fe *fieldError) Translate(ut ut.Translator) string {
 
+	if msg := fe.Message(); len(msg) > 0 {
+		return msg
+	}
+
 	m, ok := fe.v.transTagFunc[ut]
 	if !ok {
 		return fe.Error()
 	}
 
+	if fe.severity == SeverityWarning {
+		if fn, ok := m[warnTag+"("+fe.tag+")"]; ok {
+			return fn(ut, fe)
+		}
+	}
+
 	fn, ok := m[fe.tag]
 	if !ok {
 		return fe.Error()
@@ -88,7 +88,7 @@ func (v *validate) StructFieldName() string {
 
 // Param returns param for validation against current field
 func (v *validate) Param() string {
-	return v.ct.param
+	return v.paramOf(v.ct)
 }
 
 // GetStructFieldOK returns Param returns param for validation against current field
@@ -0,0 +1,308 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	notOperator     = '!'
+	groupStart      = '('
+	groupEnd        = ')'
+	invalidTagGroup = "Invalid tag group '%s' on field '%s': %s"
+)
+
+// groupOp is the operation of a node of a tag group.
+type groupOp uint8
+
+const (
+	groupLeaf groupOp = iota
+	groupAnd
+	groupOr
+	groupNot
+)
+
+// tagNode is a node of the boolean tree of a tag group eg. '(email|e164)' or
+// '!contains=test', reported by the errors of the field when it fails.
+type tagNode struct {
+	op        groupOp
+	nodes     []*tagNode // operands of and, or & not
+	ct        *cTag      // only populated for leaves
+	alias     string     // only populated for the tags of an alias
+	tag       string
+	actualTag string
+	param     string
+	cause     error // only populated for failed leaves whose validation returned an error
+}
+
+// isTagGroup reports whether the tag is a tag group, starting by a parenthesis or a
+// negation or having one after an or eg. 'email|(e164,startswith=+33)'.
+func isTagGroup(t string) bool {
+
+	if len(t) == 0 {
+		return false
+	}
+
+	if _, ok := conditionTags[strings.SplitN(t, tagKeySeparator, 2)[0]]; ok {
+		return false
+	}
+
+	return t[0] == groupStart || t[0] == notOperator ||
+		strings.Contains(t, orSeparator+string(groupStart)) || strings.Contains(t, orSeparator+string(notOperator))
+}
+
+// groupParser parses a tag group into its boolean tree, ',' being the and operator,
+// '|' the or operator binding tighter as at the top level, and '!' negating the
+// tag or group following it.
+type groupParser struct {
+	v         *Validate
+	s         string
+	pos       int
+	fieldName string
+	aliases   []string // aliases being parsed, to report recursive ones
+}
+
+// parseTagGroup parses the tag group t.
+//
+// NOTE: panics if the group is malformed or uses an undefined validation
+func (v *Validate) parseTagGroup(t string, fieldName string) *tagNode {
+
+	p := &groupParser{v: v, s: t, fieldName: fieldName}
+
+	n := p.parseAnd()
+
+	if p.pos < len(p.s) {
+		p.fail(fmt.Sprintf("unexpected '%c' at %d", p.s[p.pos], p.pos))
+	}
+
+	return n
+}
+
+func (p *groupParser) fail(problem string) {
+	panic(strings.TrimSpace(fmt.Sprintf(invalidTagGroup, p.s, p.fieldName, problem)))
+}
+
+func (p *groupParser) peek(c byte) bool {
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+// parseAnd parses operands separated by ','.
+func (p *groupParser) parseAnd() *tagNode {
+
+	start := p.pos
+	nodes := []*tagNode{p.parseOr()}
+
+	for p.peek(',') {
+		p.pos++
+		nodes = append(nodes, p.parseOr())
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+
+	text := p.s[start:p.pos]
+
+	return &tagNode{op: groupAnd, nodes: nodes, tag: text, actualTag: text}
+}
+
+// parseOr parses operands separated by '|'.
+func (p *groupParser) parseOr() *tagNode {
+
+	start := p.pos
+	nodes := []*tagNode{p.parseUnary()}
+
+	for p.peek('|') {
+		p.pos++
+		nodes = append(nodes, p.parseUnary())
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+
+	text := p.s[start:p.pos]
+
+	return &tagNode{op: groupOr, nodes: nodes, tag: text, actualTag: text}
+}
+
+// parseUnary parses a negation, a group within parentheses or a single tag.
+func (p *groupParser) parseUnary() *tagNode {
+
+	start := p.pos
+
+	switch {
+
+	case p.peek(notOperator):
+
+		p.pos++
+		n := p.parseUnary()
+
+		not := &tagNode{op: groupNot, nodes: []*tagNode{n}, param: n.param}
+
+		if n.op == groupLeaf || len(n.alias) > 0 {
+			not.tag = string(notOperator) + n.tag
+			not.actualTag = string(notOperator) + n.actualTag
+		} else {
+			not.tag = p.s[start:p.pos]
+			not.actualTag = not.tag
+		}
+
+		return not
+
+	case p.peek(groupStart):
+
+		p.pos++
+		n := p.parseAnd()
+
+		if !p.peek(groupEnd) {
+			p.fail(fmt.Sprintf("missing '%c'", groupEnd))
+		}
+		p.pos++
+
+		return n
+	}
+
+	for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != '|' && p.s[p.pos] != groupEnd {
+		p.pos++
+	}
+
+	return p.parseLeaf(p.s[start:p.pos])
+}
+
+// parseLeaf parses a single tag, or the tags of an alias.
+func (p *groupParser) parseLeaf(t string) *tagNode {
+
+	vals := strings.SplitN(t, tagKeySeparator, 2)
+	name := vals[0]
+
+	if len(name) == 0 {
+		p.fail("empty tag")
+	}
+
+	if aliasName, tags, found := p.v.expandAlias(t, p.fieldName); found {
+
+		name = aliasName
+
+		for _, a := range p.aliases {
+			if a == name {
+				p.fail(fmt.Sprintf("recursive alias '%s'", name))
+			}
+		}
+
+		ap := &groupParser{v: p.v, s: tags, fieldName: p.fieldName, aliases: append(p.aliases, name)}
+		n := ap.parseAnd()
+
+		if ap.pos < len(ap.s) {
+			ap.fail(fmt.Sprintf("unexpected '%c' at %d", ap.s[ap.pos], ap.pos))
+		}
+
+		return &tagNode{op: groupAnd, nodes: []*tagNode{n}, alias: name, tag: name, actualTag: name}
+	}
+
+	switch name {
+	case diveTag, keysTag, endKeysTag, omitempty, structOnlyTag, noStructLevelTag, isdefault:
+		p.fail(fmt.Sprintf("'%s' can't be used within a group", name))
+	}
+
+	wrapper, ok := p.v.validations[name]
+	if !ok {
+		panic(strings.TrimSpace(fmt.Sprintf(undefinedValidation, name, p.fieldName)))
+	}
+
+	ct := &cTag{
+		tag:                  name,
+		aliasTag:             name,
+		hasTag:               true,
+		hasParam:             len(vals) > 1,
+		fn:                   wrapper.fn,
+		runValidationWhenNil: wrapper.runValidatinOnNil,
+		isBlockEnd:           true,
+	}
+
+	if len(vals) > 1 {
+		ct.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
+	}
+
+	if _, ok := conditionTags[ct.tag]; ok {
+		expr, err := parseCondExpr(ct.param)
+		if err != nil {
+			panic(strings.TrimSpace(fmt.Sprintf(invalidExpression, ct.param, p.fieldName, err)))
+		}
+		ct.expr = expr
+	}
+
+	bindParamRef(ct)
+
+	return &tagNode{op: groupLeaf, ct: ct, tag: name, actualTag: name, param: ct.param}
+}
+
+// evalTagNode validates the current field using the tag group, returning the node that
+// failed, which is the failing tag for and operations and the operation itself for the
+// others, or nil if it passes.
+func (v *validate) evalTagNode(ctx context.Context, n *tagNode) *tagNode {
+
+	switch n.op {
+
+	case groupLeaf:
+
+		v.ct = n.ct
+		v.setCause(nil)
+
+		if n.ct.fn(ctx, v) {
+			return nil
+		}
+
+		if cause := v.causeOf(n.ct); len(n.ct.paramRef) > 0 || cause != nil {
+			return &tagNode{op: groupLeaf, ct: n.ct, tag: n.tag, actualTag: n.actualTag, param: v.paramOf(n.ct), cause: cause}
+		}
+		return n
+
+	case groupNot:
+
+		if v.evalTagNode(ctx, n.nodes[0]) == nil {
+			return n
+		}
+		return nil
+
+	case groupOr:
+
+		for _, o := range n.nodes {
+			if v.evalTagNode(ctx, o) == nil {
+				return nil
+			}
+		}
+		return n
+
+	default:
+
+		for _, o := range n.nodes {
+
+			failed := v.evalTagNode(ctx, o)
+			if failed == nil {
+				continue
+			}
+
+			if len(n.alias) > 0 {
+				return &tagNode{tag: n.alias, actualTag: failed.actualTag, param: failed.param, cause: failed.cause}
+			}
+			return failed
+		}
+		return nil
+	}
+}
+
+// leaves returns the tags of the group.
+func (n *tagNode) leaves(cts []*cTag) []*cTag {
+
+	if n.op == groupLeaf {
+		return append(cts, n.ct)
+	}
+
+	for _, o := range n.nodes {
+		cts = o.leaves(cts)
+	}
+
+	return cts
+}
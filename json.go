@@ -0,0 +1,627 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const trailingJSONData = "validator: invalid data after top-level value at offset %d"
+
+// errJSONLimit stops walking a document once the maximum number of errors is reached.
+var errJSONLimit = errors.New("validator: error limit reached")
+
+// JSONFieldError is a FieldError of ValidateJSON, locating the value within the
+// document using the value's JSON Pointer and byte offset.
+type JSONFieldError interface {
+	FieldError
+
+	// Offset returns the byte offset of the value within the document, or
+	// of the object it is missing from for missing keys.
+	Offset() int64
+}
+
+// compile time interface checks
+var _ JSONFieldError = new(jsonFieldError)
+
+// jsonFieldError is a fieldError located within a JSON document.
+type jsonFieldError struct {
+	*fieldError
+	pointer string
+	offset  int64
+}
+
+// JSONPointer returns the RFC 6901 JSON Pointer of the value.
+func (fe *jsonFieldError) JSONPointer() string {
+	return fe.pointer
+}
+
+// Offset returns the byte offset of the value within the document.
+func (fe *jsonFieldError) Offset() int64 {
+	return fe.offset
+}
+
+// ValidateJSON validates a JSON document, without decoding it into maps, using the
+// same rules as Map, see ValidateJSONCtx.
+func (v *Validate) ValidateJSON(data []byte, rules map[string]interface{}) error {
+	return v.ValidateJSONCtx(context.Background(), data, rules)
+}
+
+// ValidateJSONCtx validates a JSON document, without decoding it into maps, using the
+// same rules as Map and allows passing of contextual validation information via
+// context.Context.
+//
+// The document is read token by token, values are only decoded when they have tags,
+// objects and arrays with nested rules only but not tags being walked through, and
+// values without rules are skipped. The errors are in the order of the document and
+// implement JSONFieldError, locating them using a JSON Pointer and a byte offset.
+//
+// Integers are decoded as int64, or uint64 when positive and above its range, and
+// other numbers as float64 when it holds them exactly eg. 0.1, the rest being kept
+// as json.Number so that eg. min_num validates them exactly.
+//
+// It returns InvalidValidationError if the document is not an object, the decoding error
+// for malformed documents and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: panics if a rule is neither a string, a map of rules nor a slice of rules.
+func (v *Validate) ValidateJSONCtx(ctx context.Context, data []byte, rules map[string]interface{}) (err error) {
+
+	w := &jsonWalker{
+		ctx:    ctx,
+		dec:    json.NewDecoder(bytes.NewReader(data)),
+		data:   data,
+		parent: reflect.ValueOf(map[string]interface{}(nil)),
+	}
+
+	// numbers are decoded exactly, see jsonNumberValue
+	w.dec.UseNumber()
+
+	start := w.valueStart()
+
+	tok, err := w.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != json.Delim('{') {
+		if d, ok := tok.(json.Delim); ok && d == '[' {
+			return &InvalidValidationError{Type: reflect.TypeOf([]interface{}(nil))}
+		}
+		return &InvalidValidationError{Type: reflect.TypeOf(tok)}
+	}
+
+	vd := v.pool.Get().(*validate)
+	vd.top = w.parent
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
+	vd.isPartial = false
+	vd.hasGroups = false
+	vd.strictMaps = v.strictMapsFor(ctx)
+	w.v = vd
+
+	err = w.walkObject(rules, vd.ns[0:0], "", start)
+
+	if err == nil {
+		off := w.valueStart()
+		if _, tErr := w.dec.Token(); tErr != io.EOF {
+			err = fmt.Errorf(trailingJSONData, off)
+		}
+	}
+
+	if err == errJSONLimit {
+		err = nil
+	}
+
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
+
+	if err == nil && len(vd.errs) > 0 {
+		err = vd.errs
+	}
+	vd.errs = nil
+	v.pool.Put(vd)
+	return
+}
+
+// jsonWalker walks a JSON document token by token.
+type jsonWalker struct {
+	v      *validate
+	ctx    context.Context
+	dec    *json.Decoder
+	data   []byte
+	parent reflect.Value
+}
+
+// valueStart returns the offset of the next value, skipping the white space and
+// separators the decoder has not yet read.
+func (w *jsonWalker) valueStart() int64 {
+
+	off := w.dec.InputOffset()
+
+	for off < int64(len(w.data)) {
+		switch w.data[off] {
+		case ' ', '\t', '\n', '\r', ',', ':':
+			off++
+		default:
+			return off
+		}
+	}
+
+	return off
+}
+
+// walkObject walks the keys of an object whose '{' has been read, validating
+// those having rules and then the missing ones.
+func (w *jsonWalker) walkObject(rules map[string]interface{}, ns []byte, ptr string, start int64) error {
+
+	seen := make(map[string]struct{}, len(rules))
+
+	var wildcards []string
+
+	for key := range rules {
+		if strings.Contains(key, wildcardKey) {
+			wildcards = append(wildcards, key)
+		}
+	}
+
+	sort.Strings(wildcards)
+
+	for w.dec.More() {
+
+		if w.v.limitReached() {
+			return errJSONLimit
+		}
+
+		tok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key := tok.(string)
+		seen[key] = struct{}{}
+
+		keyPtr := ptr + "/" + escapeJSONPointer(key)
+
+		rule, ok := rules[key]
+		if !ok {
+			var matched []interface{}
+
+			for _, wc := range wildcards {
+				if matchWildcard(wc, key) {
+					matched = append(matched, rules[wc])
+				}
+			}
+
+			if len(matched) > 0 {
+				rule, ok = matched, true
+			}
+		}
+
+		if !ok {
+
+			valStart := w.valueStart()
+
+			if err = w.skip(); err != nil {
+				return err
+			}
+
+			if w.v.strictMaps {
+				value, _ := decodeJSONValue(w.data[valStart:w.dec.InputOffset()])
+
+				from, warnsFrom := len(w.v.errs), len(w.v.warns)
+				w.v.reportMapError(unknownKeyTag, string(append(ns, key...)), len(key), value)
+				w.locate(from, warnsFrom, ns, key, keyPtr, valStart)
+			}
+			continue
+		}
+
+		tags, nested := flattenMapRule(rule, key)
+
+		if err = w.walkValue(key, tags, nested, ns, keyPtr); err != nil {
+			return err
+		}
+	}
+
+	// closing '}'
+	if _, err := w.dec.Token(); err != nil {
+		return err
+	}
+
+	missing := make([]string, 0, len(rules))
+
+	for key := range rules {
+		if _, ok := seen[key]; !ok && !strings.Contains(key, wildcardKey) {
+			missing = append(missing, key)
+		}
+	}
+
+	sort.Strings(missing)
+
+	for _, key := range missing {
+
+		if w.v.limitReached() {
+			return errJSONLimit
+		}
+
+		tags, _ := flattenMapRule(rules[key], key)
+
+		from, warnsFrom := len(w.v.errs), len(w.v.warns)
+
+		for _, tag := range tags {
+			w.v.validateMapKey(w.ctx, w.parent, key, nil, false, tag, ns)
+		}
+
+		w.locate(from, warnsFrom, ns, key, ptr+"/"+escapeJSONPointer(key), start)
+	}
+
+	return nil
+}
+
+// walkValue walks the value of key using its tags and nested rules, the value is
+// only decoded when there are tags.
+func (w *jsonWalker) walkValue(key string, tags []string, nested []map[string]interface{}, ns []byte, ptr string) error {
+
+	start := w.valueStart()
+	from, warnsFrom := len(w.v.errs), len(w.v.warns)
+
+	tok, err := w.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+
+	if d, ok := tok.(json.Delim); ok {
+
+		switch {
+		case len(nested) == 0:
+			err = w.skipContainer()
+		case d == '{':
+			err = w.walkObject(mergeMapRules(nested), append(append(ns, key...), '.'), ptr, start)
+		default:
+			err = w.walkArray(key, mergeMapRules(nested), ns, ptr)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(tags) > 0 {
+			if value, err = decodeJSONValue(w.data[start:w.dec.InputOffset()]); err != nil {
+				return err
+			}
+		}
+
+	} else {
+
+		value = jsonNumbers(tok)
+
+		if len(nested) > 0 && value != nil {
+			w.v.reportMapError(mapTag, string(append(ns, key...)), len(key), value)
+			w.locate(from, warnsFrom, ns, key, ptr, start)
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagsFrom, tagsWarnsFrom := len(w.v.errs), len(w.v.warns)
+
+	for _, tag := range tags {
+
+		if w.v.limitReached() {
+			break
+		}
+
+		w.v.validateMapKey(w.ctx, w.parent, key, value, true, tag, ns)
+	}
+
+	w.locate(tagsFrom, tagsWarnsFrom, ns, key, ptr, start)
+
+	// errors of the value itself come before those of its content, keeping
+	// the errors in the order of the document
+	moveErrorsBefore(w.v.errs, from, tagsFrom)
+	moveErrorsBefore(w.v.warns, warnsFrom, tagsWarnsFrom)
+
+	return nil
+}
+
+// moveErrorsBefore moves the errors following tagsFrom before those from from.
+func moveErrorsBefore(errs ValidationErrors, from int, tagsFrom int) {
+
+	if tagsFrom == from || tagsFrom == len(errs) {
+		return
+	}
+
+	tagErrs := append(ValidationErrors(nil), errs[tagsFrom:]...)
+	copy(errs[from+len(tagErrs):], errs[from:tagsFrom])
+	copy(errs[from:], tagErrs)
+}
+
+// walkArray walks the elements of an array whose '[' has been read, each an object
+// validated using rules.
+func (w *jsonWalker) walkArray(key string, rules map[string]interface{}, ns []byte, ptr string) error {
+
+	for i := 0; w.dec.More(); i++ {
+
+		if w.v.limitReached() {
+			return errJSONLimit
+		}
+
+		elemKey := key + leftBracket + strconv.Itoa(i) + rightBracket
+		elemPtr := ptr + "/" + strconv.Itoa(i)
+		start := w.valueStart()
+
+		tok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		from, warnsFrom := len(w.v.errs), len(w.v.warns)
+
+		if tok == json.Delim('{') {
+
+			err = w.walkObject(rules, append(append(ns, elemKey...), '.'), elemPtr, start)
+			setIndex(w.v.errs[from:], len(ns)+len(key))
+			setIndex(w.v.warns[warnsFrom:], len(ns)+len(key))
+
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		value := jsonNumbers(tok)
+
+		if _, ok := tok.(json.Delim); ok {
+
+			if err = w.skipContainer(); err != nil {
+				return err
+			}
+
+			value, _ = decodeJSONValue(w.data[start:w.dec.InputOffset()])
+		}
+
+		w.v.reportMapError(mapTag, string(append(ns, elemKey...)), len(elemKey), value)
+		w.locate(from, warnsFrom, ns, elemKey, elemPtr, start)
+		setIndex(w.v.errs[from:], len(ns)+len(key))
+	}
+
+	// closing ']'
+	_, err := w.dec.Token()
+	return err
+}
+
+// decodeJSONValue decodes the JSON value data, its numbers being converted using
+// jsonNumberValue.
+func decodeJSONValue(data []byte) (interface{}, error) {
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var value interface{}
+
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return jsonNumbers(value), nil
+}
+
+// jsonNumbers converts the json.Number of the decoded value, and of its elements
+// for objects and arrays, using jsonNumberValue.
+func jsonNumbers(value interface{}) interface{} {
+
+	switch t := value.(type) {
+
+	case json.Number:
+		return jsonNumberValue(t)
+
+	case map[string]interface{}:
+		for k, elem := range t {
+			t[k] = jsonNumbers(elem)
+		}
+
+	case []interface{}:
+		for i, elem := range t {
+			t[i] = jsonNumbers(elem)
+		}
+	}
+
+	return value
+}
+
+// jsonNumberValue returns the number as an int64 or uint64 for integers, a float64
+// when it is exactly that of the float eg. 0.1, and as the json.Number otherwise so
+// that the validations of exact numbers eg. min_num do not see it rounded.
+func jsonNumberValue(n json.Number) interface{} {
+
+	s := n.String()
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return u
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return n
+	}
+
+	exact, ok := parseRat(s)
+	if r, rOk := parseRat(strconv.FormatFloat(f, 'g', -1, 64)); !ok || !rOk || r.Cmp(exact) != 0 {
+		return n
+	}
+
+	return f
+}
+
+// skip skips the next value.
+func (w *jsonWalker) skip() error {
+
+	tok, err := w.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := tok.(json.Delim); ok {
+		return w.skipContainer()
+	}
+
+	return nil
+}
+
+// skipContainer skips the rest of an object or array whose opening delimiter has
+// been read.
+func (w *jsonWalker) skipContainer() error {
+
+	for depth := 1; depth > 0; {
+
+		tok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if d, ok := tok.(json.Delim); ok {
+			if d == '{' || d == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+	}
+
+	return nil
+}
+
+// locate wraps the errors and warnings added since from and warnsFrom, which are those
+// of the value of key, in jsonFieldErrors. Errors within the value, eg. of dived elements,
+// are located using their namespace relative to the value and the offset of the value.
+func (w *jsonWalker) locate(from int, warnsFrom int, ns []byte, key string, ptr string, offset int64) {
+	locateErrors(w.v.errs[from:], len(ns)+len(key), ptr, offset)
+	locateErrors(w.v.warns[warnsFrom:], len(ns)+len(key), ptr, offset)
+}
+
+// locateErrors wraps the errors in jsonFieldErrors, see locate.
+func locateErrors(errs ValidationErrors, prefix int, ptr string, offset int64) {
+
+	for i := range errs {
+
+		fe, ok := errs[i].(*fieldError)
+		if !ok {
+			continue
+		}
+
+		p := ptr
+		if len(fe.ns) > prefix {
+			p += namespaceToJSONPointer(fe.ns[prefix:])
+		}
+
+		errs[i] = &jsonFieldError{fieldError: fe, pointer: p, offset: offset}
+	}
+}
+
+// flattenMapRule returns the tags and nested rules of a Map rule.
+func flattenMapRule(rule interface{}, key string) (tags []string, nested []map[string]interface{}) {
+
+	switch r := rule.(type) {
+
+	case string:
+		if len(r) > 0 && r != skipValidationTag {
+			tags = append(tags, r)
+		}
+
+	case map[string]interface{}:
+		nested = append(nested, r)
+
+	case []interface{}:
+		for _, sub := range r {
+			t, n := flattenMapRule(sub, key)
+			tags = append(tags, t...)
+			nested = append(nested, n...)
+		}
+
+	default:
+		panic(fmt.Sprintf(invalidMapRule, rule, key))
+	}
+
+	return
+}
+
+// mergeMapRules merges nested rules so an object is only walked once, the rules
+// of a key found in several of them being applied in turn.
+func mergeMapRules(nested []map[string]interface{}) map[string]interface{} {
+
+	if len(nested) == 1 {
+		return nested[0]
+	}
+
+	merged := make(map[string]interface{})
+
+	for _, rules := range nested {
+		for key, rule := range rules {
+			if existing, ok := merged[key]; ok {
+				merged[key] = []interface{}{existing, rule}
+			} else {
+				merged[key] = rule
+			}
+		}
+	}
+
+	return merged
+}
+
+// escapeJSONPointer escapes a reference token of a JSON Pointer.
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// namespaceToJSONPointer converts a namespace relative to a value, eg. '[2].zip',
+// to the JSON Pointer relative to the value, eg. '/2/zip'.
+func namespaceToJSONPointer(ns string) string {
+
+	var b strings.Builder
+
+	for len(ns) > 0 {
+
+		var token string
+
+		switch ns[0] {
+
+		case '[':
+			end := strings.Index(ns, rightBracket)
+			if end < 0 {
+				end = len(ns) - 1
+			}
+			token, ns = ns[1:end], ns[end+1:]
+
+		case '.':
+			ns = ns[1:]
+			continue
+
+		default:
+			end := strings.IndexAny(ns, ".[")
+			if end < 0 {
+				end = len(ns)
+			}
+			token, ns = ns[:end], ns[end:]
+		}
+
+		b.WriteByte('/')
+		b.WriteString(escapeJSONPointer(token))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,288 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	wildcardKey    = "*"
+	unknownKeyTag  = "unknown_key"
+	mapTag         = "map"
+	invalidMapRule = "Invalid rule of type '%T' for key '%s'"
+)
+
+// Map validates JSON-like data, eg. decoded using encoding/json, using a map of rules
+// keyed by the keys of the data, see MapCtx.
+func (v *Validate) Map(data interface{}, rules map[string]interface{}) error {
+	return v.MapCtx(context.Background(), data, rules)
+}
+
+// MapCtx validates JSON-like data, eg. decoded using encoding/json, using a map of rules
+// keyed by the keys of the data and allows passing of contextual validation information
+// via context.Context. The rule of a key is either:
+//
+//	string                 the tags of the value eg. "required,dive,email"
+//	map[string]interface{} the rules of the value, an object, or of each of the objects
+//	                       of the value when it is an array
+//	[]interface{}          rules applied in turn eg. []interface{}{"required,min=1", map[string]interface{}{...}}
+//
+// Keys containing '*' are wildcards, whose rules apply to any keys of the data they match
+// that have no rule of their own, eg. "*" or "attr_*". A key missing from the data is only
+// validated when its tags start by 'required' or a conditional one such as 'required_if',
+// run like for nil struct fields eg. "required_if=[payment] card", while nested rules of
+// a missing key are skipped.
+//
+// Errors are namespaced by their path within the data eg. 'user.addresses[2].zip'. The
+// values of nested rules that are not objects are reported using the 'map' tag and, in
+// strict mode, keys of the data without any rule using the 'unknown_key' tag, see
+// SetStrictMaps and WithStrictMaps.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+//
+// NOTE: panics if a rule is neither a string, a map of rules nor a slice of rules.
+func (v *Validate) MapCtx(ctx context.Context, data interface{}, rules map[string]interface{}) (err error) {
+
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return &InvalidValidationError{Type: reflect.TypeOf(data)}
+	}
+
+	vd := v.pool.Get().(*validate)
+	vd.top = reflect.ValueOf(m)
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
+	vd.isPartial = false
+	vd.hasGroups = false
+	vd.strictMaps = v.strictMapsFor(ctx)
+
+	vd.validateMap(ctx, m, rules, vd.ns[0:0])
+
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
+
+	if len(vd.errs) > 0 {
+		err = vd.errs
+		vd.errs = nil
+	}
+	v.pool.Put(vd)
+	return
+}
+
+// strictMapsFor returns whether Map rejects the keys without rules for a validation
+// using ctx.
+func (v *Validate) strictMapsFor(ctx context.Context) bool {
+	if ctx != nil {
+		if strict, ok := ctx.Value(strictMapsCtxKey).(bool); ok {
+			return strict
+		}
+	}
+	return v.strictMaps
+}
+
+// validateMap validates the keys of data having rules, in order, followed by the keys
+// only matched by wildcard rules.
+func (v *validate) validateMap(ctx context.Context, data map[string]interface{}, rules map[string]interface{}, ns []byte) {
+
+	parent := reflect.ValueOf(data)
+
+	keys := make([]string, 0, len(rules))
+	var wildcards []string
+
+	for key := range rules {
+		if strings.Contains(key, wildcardKey) {
+			wildcards = append(wildcards, key)
+		} else {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	sort.Strings(wildcards)
+
+	for _, key := range keys {
+
+		if v.limitReached() {
+			return
+		}
+
+		value, found := data[key]
+		v.validateMapKey(ctx, parent, key, value, found, rules[key], ns)
+	}
+
+	if len(wildcards) == 0 && !v.strictMaps {
+		return
+	}
+
+	keys = keys[:0]
+
+	for key := range data {
+		if _, ok := rules[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+
+		matched := false
+
+		for _, w := range wildcards {
+
+			if v.limitReached() {
+				return
+			}
+
+			if matchWildcard(w, key) {
+				matched = true
+				v.validateMapKey(ctx, parent, key, data[key], true, rules[w], ns)
+			}
+		}
+
+		if !matched && v.strictMaps && !v.limitReached() {
+			v.reportMapError(unknownKeyTag, string(append(ns, key...)), len(key), data[key])
+		}
+	}
+}
+
+// validateMapKey validates the value of key using its rule.
+func (v *validate) validateMapKey(ctx context.Context, parent reflect.Value, key string, value interface{}, found bool, rule interface{}, ns []byte) {
+
+	switch r := rule.(type) {
+
+	case string:
+
+		if len(r) == 0 || r == skipValidationTag {
+			return
+		}
+
+		ct := v.v.fetchCacheTag(r)
+		current := reflect.ValueOf(value)
+
+		if !found {
+
+			if ct == nil || (ct.tag != requiredTag && !ct.runValidationWhenNil) {
+				return
+			}
+
+			// like nil struct fields, missing keys are validated by the validations
+			// run even if the value is nil eg. required_if
+			if ct.tag != requiredTag {
+				current = reflect.Zero(interfaceType)
+			}
+		}
+
+		cf := &cField{name: key, altName: key, namesEqual: true, mayWrap: true, validateMethod: true}
+		v.traverseField(ctx, parent, current, ns, ns, cf, ct)
+
+	case map[string]interface{}:
+
+		if !found || value == nil {
+			return
+		}
+
+		if m, ok := value.(map[string]interface{}); ok {
+			v.validateMap(ctx, m, r, append(append(ns, key...), '.'))
+			return
+		}
+
+		current := reflect.ValueOf(value)
+
+		if current.Kind() != reflect.Slice && current.Kind() != reflect.Array {
+			v.reportMapError(mapTag, string(append(ns, key...)), len(key), value)
+			return
+		}
+
+		for i := 0; i < current.Len(); i++ {
+
+			if v.limitReached() {
+				return
+			}
+
+			v.misc = append(v.misc[0:0], key...)
+			v.misc = append(v.misc, '[')
+			v.misc = strconv.AppendInt(v.misc, int64(i), 10)
+			v.misc = append(v.misc, ']')
+
+			elemNs := append(ns, v.misc...)
+			elem := current.Index(i).Interface()
+			errsFrom, warnsFrom := len(v.errs), len(v.warns)
+
+			if m, ok := elem.(map[string]interface{}); ok {
+				v.validateMap(ctx, m, r, append(elemNs, '.'))
+			} else {
+				v.reportMapError(mapTag, string(elemNs), len(v.misc), elem)
+			}
+
+			setIndex(v.errs[errsFrom:], len(ns)+len(key))
+			setIndex(v.warns[warnsFrom:], len(ns)+len(key))
+		}
+
+	case []interface{}:
+
+		for _, sub := range r {
+
+			if v.limitReached() {
+				return
+			}
+
+			v.validateMapKey(ctx, parent, key, value, found, sub, ns)
+		}
+
+	default:
+		panic(fmt.Sprintf(invalidMapRule, rule, key))
+	}
+}
+
+// reportMapError reports the value found at the namespace ns, whose field name is the
+// last fieldLen bytes, as failing the tag.
+func (v *validate) reportMapError(tag string, ns string, fieldLen int, value interface{}) {
+
+	fe := &fieldError{
+		v:              v.v,
+		tag:            tag,
+		actualTag:      tag,
+		ns:             ns,
+		structNs:       ns,
+		fieldLen:       uint8(fieldLen),
+		structfieldLen: uint8(fieldLen),
+		value:          value,
+	}
+
+	if current := reflect.ValueOf(value); current.IsValid() {
+		fe.kind = current.Kind()
+		fe.typ = current.Type()
+	}
+
+	v.errs = append(v.errs, fe)
+}
+
+// matchWildcard reports whether key matches the pattern, in which '*' matches
+// any sequence of characters.
+func matchWildcard(pattern string, key string) bool {
+
+	parts := strings.Split(pattern, wildcardKey)
+
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+
+	last := len(parts) - 1
+
+	for _, p := range parts[1:last] {
+		i := strings.Index(key, p)
+		if i < 0 {
+			return false
+		}
+		key = key[i+len(p):]
+	}
+
+	return strings.HasSuffix(key, parts[last])
+}
@@ -0,0 +1,163 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	messageTagName = "msg"
+	codeTagName    = "errcode"
+)
+
+// cMessages are the custom messages and error codes of a field, set using the
+// 'msg' and 'errcode' companion tags, keyed by validation tag. The key of the
+// tags without a validation tag suffix is empty.
+type cMessages struct {
+	messages map[string]string
+	codes    map[string]string
+}
+
+// parseMessages returns the custom messages and error codes of the field tag,
+// or nil when there are none.
+//
+// eg. `msg:"{field} is invalid" msg_min:"{field} needs at least {param} characters" errcode:"NAME_INVALID"`
+func parseMessages(tag reflect.StructTag) *cMessages {
+
+	if !strings.Contains(string(tag), messageTagName) && !strings.Contains(string(tag), codeTagName) {
+		return nil
+	}
+
+	var m *cMessages
+
+	for tag != "" {
+
+		// skip leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// scan to colon, a space, a quote or a control character is a syntax error
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		name := string(tag[:i])
+		tag = tag[i+1:]
+
+		// scan quoted string to find value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		qvalue := string(tag[:i+1])
+		tag = tag[i+1:]
+
+		value, err := strconv.Unquote(qvalue)
+		if err != nil {
+			break
+		}
+
+		if key, ok := companionTagKey(name, messageTagName); ok {
+			if m == nil {
+				m = new(cMessages)
+			}
+			if m.messages == nil {
+				m.messages = make(map[string]string)
+			}
+			m.messages[key] = value
+			continue
+		}
+
+		if key, ok := companionTagKey(name, codeTagName); ok {
+			if m == nil {
+				m = new(cMessages)
+			}
+			if m.codes == nil {
+				m.codes = make(map[string]string)
+			}
+			m.codes[key] = value
+		}
+	}
+
+	return m
+}
+
+// companionTagKey returns the validation tag of the companion tag name eg. 'min'
+// for 'msg_min', which is empty for the companion tag itself eg. 'msg'.
+func companionTagKey(name string, companion string) (string, bool) {
+
+	if name == companion {
+		return "", true
+	}
+
+	if strings.HasPrefix(name, companion) && len(name) > len(companion)+1 && name[len(companion)] == '_' {
+		return name[len(companion)+1:], true
+	}
+
+	return "", false
+}
+
+// lookupMessage returns the value of the validation tag, or the actual tag within an alias,
+// falling back to the value without a validation tag.
+func lookupMessage(values map[string]string, tag string, actualTag string) string {
+
+	if s, ok := values[tag]; ok {
+		return s
+	}
+
+	if s, ok := values[actualTag]; ok {
+		return s
+	}
+
+	return values[""]
+}
+
+// Message returns the custom message of the field set using the 'msg' companion tag,
+// or 'msg_<tag>' for the tag that failed, with the placeholders {field}, {value},
+// {param} and {tag} replaced, or an empty string when there is none.
+func (fe *fieldError) Message() string {
+
+	if fe.messages == nil {
+		return ""
+	}
+
+	msg := lookupMessage(fe.messages.messages, fe.tag, fe.actualTag)
+	if len(msg) == 0 {
+		return ""
+	}
+
+	return strings.NewReplacer(
+		"{field}", fe.Field(),
+		"{value}", fmt.Sprintf("%v", fe.value),
+		"{param}", fe.param,
+		"{tag}", fe.tag,
+	).Replace(msg)
+}
+
+// Code returns the error code of the field set using the 'errcode' companion tag,
+// or 'errcode_<tag>' for the tag that failed, or an empty string when there is none.
+func (fe *fieldError) Code() string {
+
+	if fe.messages == nil {
+		return ""
+	}
+
+	return lookupMessage(fe.messages.codes, fe.tag, fe.actualTag)
+}
@@ -0,0 +1,232 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+const (
+	methodTag       = "method"
+	methodNotFound  = "Method '%s' not found on '%s' or the type of field '%s'"
+	badMethodSig    = "Bad method '%s' of '%s', expected func() bool, func() error or func(context.Context) error"
+	emptyMethodName = "Missing method name of the 'method' tag on field '%s'"
+)
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// methodKind is the signature of a method called by the 'method' tag.
+type methodKind uint8
+
+const (
+	methodBool methodKind = iota
+	methodErr
+	methodCtxErr
+)
+
+// cMethod is a method called by the 'method' tag, of the struct containing the field
+// or of the field's type.
+type cMethod struct {
+	typ     reflect.Type // type of the receiver, not a pointer to it
+	index   int          // index of the method within the method set of typ, or of its pointer
+	onPtr   bool         // true if the method is that of the pointer to typ
+	onField bool         // true if the method is that of the field's type
+	kind    methodKind
+}
+
+// findMethod returns the method of the type, or of the pointer to it, named name and
+// whether it has one.
+//
+// NOTE: panics if the method doesn't have any of the signatures of the 'method' tag
+func findMethod(typ reflect.Type, name string) (*cMethod, bool) {
+
+	// the methods of interfaces are those of the values they hold
+	if typ.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	m := &cMethod{typ: typ}
+
+	method, ok := typ.MethodByName(name)
+	if !ok {
+		if method, ok = reflect.PtrTo(typ).MethodByName(name); !ok {
+			return nil, false
+		}
+		m.onPtr = true
+	}
+
+	m.index = method.Index
+
+	// the first input of the method is its receiver
+	mt := method.Type
+
+	switch {
+	case mt.NumIn() == 1 && mt.NumOut() == 1 && mt.Out(0).Kind() == reflect.Bool:
+		m.kind = methodBool
+	case mt.NumIn() == 1 && mt.NumOut() == 1 && mt.Out(0) == errorType:
+		m.kind = methodErr
+	case mt.NumIn() == 2 && mt.In(1) == contextType && mt.NumOut() == 1 && mt.Out(0) == errorType:
+		m.kind = methodCtxErr
+	default:
+		panic(fmt.Sprintf(badMethodSig, name, typ))
+	}
+
+	return m, true
+}
+
+// fieldMethods returns the methods called by the 'method' tags of the field of the
+// struct typ, keyed by name, looked up on the struct first then on the field's type
+// or the type of its elements.
+//
+// Methods of fields of interface types are only known when validating.
+//
+// NOTE: panics if a method is not found or has the wrong signature
+func (v *Validate) fieldMethods(typ reflect.Type, fld reflect.StructField, ct *cTag) map[string]*cMethod {
+
+	var methods map[string]*cMethod
+
+	for _, name := range methodNames(ct, nil) {
+
+		if len(name) == 0 {
+			panic(fmt.Sprintf(emptyMethodName, fld.Name))
+		}
+
+		if _, ok := methods[name]; ok {
+			continue
+		}
+
+		m, ok := findMethod(typ, name)
+
+		for ft := fld.Type; !ok; {
+
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if m, ok = findMethod(ft, name); ok {
+				m.onField = true
+				break
+			}
+
+			if ft.Kind() == reflect.Interface {
+				break
+			}
+
+			switch ft.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				ft = ft.Elem()
+			default:
+				panic(fmt.Sprintf(methodNotFound, name, typ, fld.Name))
+			}
+		}
+
+		if !ok {
+			continue
+		}
+
+		if methods == nil {
+			methods = make(map[string]*cMethod)
+		}
+		methods[name] = m
+	}
+
+	return methods
+}
+
+// methodNames appends the names of the methods called by the 'method' tags of the
+// chain of tags to names.
+func methodNames(ct *cTag, names []string) []string {
+
+	for ; ct != nil; ct = ct.next {
+
+		if ct.keys != nil {
+			names = methodNames(ct.keys, names)
+		}
+
+		if ct.typeof == typeGroup {
+			for _, leaf := range ct.group.leaves(nil) {
+				names = methodNames(leaf, names)
+			}
+			continue
+		}
+
+		if ct.tag == methodTag && ct.fn != nil {
+			names = append(names, ct.param)
+		}
+	}
+
+	return names
+}
+
+// isMethod is the validation function for validating the current field by calling the param's
+// method of the struct containing it, or of the field's type, which must have one of the
+// signatures func() bool, func() error or func(context.Context) error.
+func isMethod(ctx context.Context, fl FieldLevel) bool {
+
+	v := fl.(*validate)
+	name := fl.Param()
+
+	if len(name) == 0 {
+		panic(fmt.Sprintf(emptyMethodName, v.cf.name))
+	}
+
+	m, ok := v.cf.methods[name]
+
+	recv := v.slflParent
+	if !ok || m.onField {
+		recv = v.flField
+	}
+
+	// the cached method may be that of other elements, or not be known before validating
+	// an interface field, such as when validating variables
+	if !ok || recv.Type() != m.typ {
+
+		if m, ok = findMethod(recv.Type(), name); !ok {
+			panic(fmt.Sprintf(methodNotFound, name, recv.Type(), v.cf.name))
+		}
+	}
+
+	valid, err := callMethod(ctx, recv, m)
+	v.setCause(err)
+
+	return valid
+}
+
+// callMethod calls the method of recv, returning whether it passes and the error it
+// returned, if any.
+func callMethod(ctx context.Context, recv reflect.Value, m *cMethod) (bool, error) {
+
+	if m.onPtr {
+		if recv.CanAddr() {
+			recv = recv.Addr()
+		} else {
+			ptr := reflect.New(recv.Type())
+			ptr.Elem().Set(recv)
+			recv = ptr
+		}
+	}
+
+	var in []reflect.Value
+
+	if m.kind == methodCtxErr {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		in = []reflect.Value{reflect.ValueOf(ctx)}
+	}
+
+	out := recv.Method(m.index).Call(in)[0]
+
+	if m.kind == methodBool {
+		return out.Bool(), nil
+	}
+
+	if out.IsNil() {
+		return true, nil
+	}
+
+	return false, out.Interface().(error)
+}
@@ -0,0 +1,286 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ModifierFunc accepts a FieldLevel interface for all modification needs, the
+// field returned by FieldLevel.Field() is settable and pointers to it are only
+// dereferenced when not nil.
+type ModifierFunc func(fl FieldLevel)
+
+// ModifierFuncCtx accepts a context.Context and FieldLevel interface for all
+// modification needs, see ModifierFunc.
+type ModifierFuncCtx func(ctx context.Context, fl FieldLevel)
+
+// wrapModifier wraps a ModifierFunc in a ModifierFuncCtx.
+func wrapModifier(fn ModifierFunc) ModifierFuncCtx {
+	if fn == nil {
+		return nil // be sure not to wrap a bad function.
+	}
+	return func(ctx context.Context, fl FieldLevel) {
+		fn(fl)
+	}
+}
+
+var (
+	// bakedInModifiers are the default modifiers
+	// keep alphabetical for readability
+	bakedInModifiers = map[string]ModifierFunc{
+		"default": setDefault,
+		"lcase":   toLower,
+		"ltrim":   trimLeft,
+		"nfc":     normalizeNFC,
+		"nfd":     normalizeNFD,
+		"nfkc":    normalizeNFKC,
+		"nfkd":    normalizeNFKD,
+		"rtrim":   trimRight,
+		"trim":    trimSpace,
+		"ucase":   toUpper,
+	}
+)
+
+// parseModifiers parses the value of the 'mod' companion tag into a chain of
+// cTags whose modifier functions are run in order.
+func (v *Validate) parseModifiers(tag string, fieldName string) (first *cTag) {
+
+	if len(tag) == 0 {
+		return
+	}
+
+	var current *cTag
+
+	for _, t := range strings.Split(tag, tagSeparator) {
+
+		vals := strings.SplitN(strings.TrimSpace(t), tagKeySeparator, 2)
+
+		ct := &cTag{tag: vals[0], aliasTag: vals[0], hasTag: true, hasParam: len(vals) > 1}
+
+		if len(ct.tag) == 0 {
+			panic(strings.TrimSpace(fmt.Sprintf(invalidModifier, fieldName)))
+		}
+
+		fn, ok := v.modifiers[ct.tag]
+		if !ok {
+			panic(strings.TrimSpace(fmt.Sprintf(undefinedModifier, ct.tag, fieldName)))
+		}
+		ct.mod = fn
+
+		if len(vals) > 1 {
+			ct.param = strings.Replace(vals[1], utf8HexComma, ",", -1)
+		}
+
+		if current == nil {
+			first = ct
+		} else {
+			current.next = ct
+		}
+		current = ct
+	}
+
+	return
+}
+
+// modifyStruct runs the modifiers of the fields of current, a settable struct,
+// and of any nested structs.
+func (v *validate) modifyStruct(ctx context.Context, current reflect.Value, typ reflect.Type) {
+
+	cs, ok := v.v.structCache.Get(typ)
+	if !ok {
+		cs = v.v.extractStructCache(current, typ.Name())
+	}
+
+	var f *cField
+	var fld reflect.Value
+
+	for i := 0; i < len(cs.fields); i++ {
+
+		f = cs.fields[i]
+		fld = current.Field(f.idx)
+
+		if f.mods != nil && fld.CanSet() {
+
+			v.slflParent = current
+			v.cf = f
+			v.fldIsPointer = fld.Kind() == reflect.Ptr
+
+			for ct := f.mods; ct != nil; ct = ct.next {
+
+				// dereferenced each time as a previous modifier, eg. default,
+				// may have set a nil pointer
+				v.flField = fld
+				for v.flField.Kind() == reflect.Ptr && !v.flField.IsNil() {
+					v.flField = v.flField.Elem()
+				}
+
+				v.ct = ct
+				ct.mod(ctx, v)
+			}
+		}
+
+		v.modifyNested(ctx, fld)
+	}
+}
+
+// modifyNested runs the modifiers of the structs held by current, be it a struct
+// or a slice or array of structs.
+func (v *validate) modifyNested(ctx context.Context, current reflect.Value) {
+
+	for current.Kind() == reflect.Ptr && !current.IsNil() {
+		current = current.Elem()
+	}
+
+	switch current.Kind() {
+
+	case reflect.Struct:
+		if current.Type() != timeType {
+			v.modifyStruct(ctx, current, current.Type())
+		}
+
+	case reflect.Slice, reflect.Array:
+
+		typ := current.Type().Elem()
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() != reflect.Struct || typ == timeType {
+			return
+		}
+
+		for i := 0; i < current.Len(); i++ {
+			v.modifyNested(ctx, current.Index(i))
+		}
+	}
+}
+
+// modifyString replaces the value of a string field with the result of fn.
+func modifyString(fl FieldLevel, fn func(string) string) {
+
+	field := fl.Field()
+
+	if field.Kind() == reflect.String && field.CanSet() {
+		field.SetString(fn(field.String()))
+	}
+}
+
+// trimSpace is the modifier function
+// Removes the leading and trailing white space of strings.
+func trimSpace(fl FieldLevel) {
+	modifyString(fl, strings.TrimSpace)
+}
+
+// trimLeft is the modifier function
+// Removes the leading white space of strings.
+func trimLeft(fl FieldLevel) {
+	modifyString(fl, func(s string) string {
+		return strings.TrimLeftFunc(s, unicode.IsSpace)
+	})
+}
+
+// trimRight is the modifier function
+// Removes the trailing white space of strings.
+func trimRight(fl FieldLevel) {
+	modifyString(fl, func(s string) string {
+		return strings.TrimRightFunc(s, unicode.IsSpace)
+	})
+}
+
+// toLower is the modifier function
+// Maps strings to lower case.
+func toLower(fl FieldLevel) {
+	modifyString(fl, strings.ToLower)
+}
+
+// toUpper is the modifier function
+// Maps strings to upper case.
+func toUpper(fl FieldLevel) {
+	modifyString(fl, strings.ToUpper)
+}
+
+// normalizeNFC is the modifier function
+// Normalizes strings to Unicode Normalization Form C.
+func normalizeNFC(fl FieldLevel) {
+	modifyString(fl, norm.NFC.String)
+}
+
+// normalizeNFD is the modifier function
+// Normalizes strings to Unicode Normalization Form D.
+func normalizeNFD(fl FieldLevel) {
+	modifyString(fl, norm.NFD.String)
+}
+
+// normalizeNFKC is the modifier function
+// Normalizes strings to Unicode Normalization Form KC.
+func normalizeNFKC(fl FieldLevel) {
+	modifyString(fl, norm.NFKC.String)
+}
+
+// normalizeNFKD is the modifier function
+// Normalizes strings to Unicode Normalization Form KD.
+func normalizeNFKD(fl FieldLevel) {
+	modifyString(fl, norm.NFKD.String)
+}
+
+// setDefault is the modifier function
+// Sets the field to the param when it is the zero value or a nil pointer, for
+// strings, numbers, durations and bools.
+func setDefault(fl FieldLevel) {
+
+	field := fl.Field()
+
+	if !field.CanSet() {
+		return
+	}
+
+	if field.Kind() == reflect.Ptr {
+
+		if !field.IsNil() {
+			return
+		}
+
+		elem := reflect.New(field.Type().Elem())
+		if setParamValue(elem.Elem(), fl.Param()) {
+			field.Set(elem)
+		}
+		return
+	}
+
+	if field.IsZero() {
+		setParamValue(field, fl.Param())
+	}
+}
+
+// setParamValue sets field to the param converted to its kind, reporting
+// whether the kind is supported.
+func setParamValue(field reflect.Value, param string) bool {
+
+	switch field.Kind() {
+
+	case reflect.String:
+		field.SetString(param)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(asIntFromType(field.Type(), param))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		field.SetUint(asUint(param))
+
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(asFloat(param))
+
+	case reflect.Bool:
+		field.SetBool(asBool(param))
+
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const badDecimalParam = "Bad decimal param '%s', expected precision and scale eg. 'decimal=10 2'"
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+
+	bigTen = big.NewInt(10)
+)
+
+// isBigNumberType reports whether the type is one of the arbitrary-precision numbers of
+// math/big, which are validated as numbers rather than as structs.
+func isBigNumberType(typ reflect.Type) bool {
+	return typ == bigIntType || typ == bigFloatType || typ == bigRatType
+}
+
+// fieldRat returns the exact value of the field, an integer, a float, a numeric
+// string eg. json.Number or a number of math/big, and whether a string is a number.
+//
+// Floats are converted using the shortest decimal representing them so that eg.
+// 0.1 is 1/10 rather than its binary approximation.
+//
+// NOTE: panics if the field is not a number
+func fieldRat(field reflect.Value) (*big.Rat, bool) {
+
+	switch field.Kind() {
+
+	case reflect.String:
+		return parseRat(field.String())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(field.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(field.Uint())), true
+
+	case reflect.Float32, reflect.Float64:
+		bits := 64
+		if field.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return parseRat(strconv.FormatFloat(field.Float(), 'g', -1, bits))
+
+	case reflect.Struct:
+
+		if isBigNumberType(field.Type()) {
+
+			ptr := reflect.New(field.Type())
+			ptr.Elem().Set(field)
+
+			switch n := ptr.Interface().(type) {
+			case *big.Int:
+				return new(big.Rat).SetInt(n), true
+			case *big.Float:
+				if n.IsInf() {
+					return nil, false
+				}
+				return parseRat(n.Text('g', -1))
+			case *big.Rat:
+				return n, true
+			}
+		}
+	}
+
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}
+
+// parseRat returns the exact value of the decimal number s eg. '-12.50' or '1.2e3'.
+func parseRat(s string) (*big.Rat, bool) {
+
+	if !decimalNumberRegex.MatchString(s) {
+		return nil, false
+	}
+
+	return new(big.Rat).SetString(s)
+}
+
+// asRat returns the parameter as an exact number
+// or panics if it can't convert
+func asRat(param string) *big.Rat {
+
+	r, ok := parseRat(param)
+	if !ok {
+		panic(fmt.Sprintf("Bad number param '%s'", param))
+	}
+
+	return r
+}
+
+// compareNum compares the exact values of the current field and the param, reporting
+// false for fields that are not numbers.
+func compareNum(fl FieldLevel) (int, bool) {
+
+	r, ok := fieldRat(fl.Field())
+	if !ok {
+		return 0, false
+	}
+
+	return r.Cmp(asRat(fl.Param())), true
+}
+
+// isMinNum is the validation function for validating if the current field's exact numeric
+// value is greater than or equal to the param's value.
+func isMinNum(fl FieldLevel) bool {
+	c, ok := compareNum(fl)
+	return ok && c >= 0
+}
+
+// isMaxNum is the validation function for validating if the current field's exact numeric
+// value is less than or equal to the param's value.
+func isMaxNum(fl FieldLevel) bool {
+	c, ok := compareNum(fl)
+	return ok && c <= 0
+}
+
+// isGtNum is the validation function for validating if the current field's exact numeric
+// value is greater than the param's value.
+func isGtNum(fl FieldLevel) bool {
+	c, ok := compareNum(fl)
+	return ok && c > 0
+}
+
+// isLtNum is the validation function for validating if the current field's exact numeric
+// value is less than the param's value.
+func isLtNum(fl FieldLevel) bool {
+	c, ok := compareNum(fl)
+	return ok && c < 0
+}
+
+// isMultipleOf is the validation function for validating if the current field's exact numeric
+// value is a multiple of the param's value eg. 'multiple_of=0.05'.
+func isMultipleOf(fl FieldLevel) bool {
+
+	r, ok := fieldRat(fl.Field())
+	if !ok {
+		return false
+	}
+
+	m := asRat(fl.Param())
+	if m.Sign() == 0 {
+		panic(fmt.Sprintf("Bad number param '%s'", fl.Param()))
+	}
+
+	return new(big.Rat).Quo(r, m).IsInt()
+}
+
+// isDecimal is the validation function for validating if the current field's exact numeric
+// value fits a decimal of the param's precision and scale, the total number of digits and
+// the number of digits after the decimal point eg. 'decimal=10 2' like SQL's DECIMAL(10,2).
+func isDecimal(fl FieldLevel) bool {
+
+	r, ok := fieldRat(fl.Field())
+	if !ok {
+		return false
+	}
+
+	precision, scale := parseDecimalParam(fl.Param())
+
+	// the value scaled by 10^scale must be an integer of at most precision digits
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(bigTen, big.NewInt(int64(scale)), nil)))
+	if !scaled.IsInt() {
+		return false
+	}
+
+	limit := new(big.Int).Exp(bigTen, big.NewInt(int64(precision)), nil)
+
+	return new(big.Int).Abs(scaled.Num()).Cmp(limit) < 0
+}
+
+// parseDecimalParam returns the precision and scale of the 'decimal' param, separated
+// by a space or an escaped comma.
+//
+// NOTE: panics if the param is not a precision followed by a scale no greater than it
+func parseDecimalParam(param string) (precision int, scale int) {
+
+	parts := strings.FieldsFunc(param, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+
+	if len(parts) != 2 {
+		panic(fmt.Sprintf(badDecimalParam, param))
+	}
+
+	precision, err := strconv.Atoi(parts[0])
+	if err != nil || precision <= 0 {
+		panic(fmt.Sprintf(badDecimalParam, param))
+	}
+
+	scale, err = strconv.Atoi(parts[1])
+	if err != nil || scale < 0 || scale > precision {
+		panic(fmt.Sprintf(badDecimalParam, param))
+	}
+
+	return precision, scale
+}
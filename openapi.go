@@ -0,0 +1,201 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	openAPISchemasPrefix = "#/components/schemas/"
+	yamlIndent           = "  "
+)
+
+// OpenAPIComponents is the 'components' object of an OpenAPI 3.1 document
+// holding the schemas generated from the validation tags of structs.
+//
+// OpenAPI 3.1 schemas are JSON Schema Draft 2020-12, and so are generated
+// exactly like JSONSchema except that nested types are referenced using
+// '#/components/schemas/<Name>'.
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// OpenAPIComponents generates the OpenAPI 3.1 'components.schemas' entries
+// for the struct types of the values passed in, along with all of the named
+// struct types they reference.
+//
+// It returns InvalidValidationError for bad values passed in.
+func (v *Validate) OpenAPIComponents(types ...interface{}) (*OpenAPIComponents, error) {
+
+	g := newSchemaGenerator(v, openAPISchemasPrefix)
+
+	for _, t := range types {
+
+		typ := reflect.TypeOf(t)
+
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		// anonymous structs have no name to be registered under
+		if typ == nil || typ.Kind() != reflect.Struct || typ == timeType || typ.Name() == "" {
+			return nil, &InvalidValidationError{Type: reflect.TypeOf(t)}
+		}
+
+		g.structSchema(typ)
+	}
+
+	return &OpenAPIComponents{Schemas: g.defs}, nil
+}
+
+// JSON returns the components encoded as indented JSON.
+func (c *OpenAPIComponents) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", yamlIndent)
+}
+
+// YAML returns the components encoded as YAML, ready to be placed under
+// the 'components' key of an OpenAPI document.
+func (c *OpenAPIComponents) YAML() ([]byte, error) {
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	n, err := decodeYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	buff := new(bytes.Buffer)
+	n.write(buff, 0, false)
+
+	return buff.Bytes(), nil
+}
+
+// yamlNode is a JSON value whose object keys are kept in document order so
+// that the YAML output mirrors the JSON one.
+type yamlNode struct {
+	delim  json.Delim // '{', '[' or 0 for scalars
+	keys   []string
+	values []*yamlNode
+	scalar string
+}
+
+// decodeYAMLNode reads the next JSON value of dec.
+func decodeYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	n := new(yamlNode)
+
+	switch t := tok.(type) {
+
+	case json.Delim:
+
+		n.delim = t
+
+		for dec.More() {
+
+			if t == '{' {
+				if tok, err = dec.Token(); err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, tok.(string))
+			}
+
+			child, err := decodeYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, child)
+		}
+
+		// consume the closing delimiter
+		_, err = dec.Token()
+		return n, err
+
+	case string:
+		n.scalar = yamlString(t)
+
+	case json.Number:
+		n.scalar = t.String()
+
+	case bool:
+		n.scalar = strconv.FormatBool(t)
+
+	case nil:
+		n.scalar = "null"
+	}
+
+	return n, nil
+}
+
+// isCollection reports if the node is a non empty object or array, these are
+// written in block style on the lines following their key.
+func (n *yamlNode) isCollection() bool {
+	return n.delim != 0 && len(n.values) > 0
+}
+
+// write writes the node in block style YAML. inline indicates that the node
+// follows a '- ' sequence marker and so its first line is already indented.
+func (n *yamlNode) write(w *bytes.Buffer, depth int, inline bool) {
+
+	if !n.isCollection() {
+
+		switch n.delim {
+		case '{':
+			w.WriteString("{}")
+		case '[':
+			w.WriteString("[]")
+		default:
+			w.WriteString(n.scalar)
+		}
+
+		w.WriteString("\n")
+		return
+	}
+
+	indent := strings.Repeat(yamlIndent, depth)
+
+	for i, child := range n.values {
+
+		if i > 0 || !inline {
+			w.WriteString(indent)
+		}
+
+		if n.delim == '[' {
+			w.WriteString("- ")
+			child.write(w, depth+1, true)
+			continue
+		}
+
+		w.WriteString(yamlString(n.keys[i]))
+		w.WriteString(":")
+
+		if child.isCollection() {
+			w.WriteString("\n")
+			child.write(w, depth+1, false)
+			continue
+		}
+
+		w.WriteString(" ")
+		child.write(w, depth+1, false)
+	}
+}
+
+// yamlString returns s as a double quoted YAML scalar, JSON string escaping
+// is a subset of the YAML double quoted style.
+func yamlString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
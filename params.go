@@ -0,0 +1,213 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const fieldParamPrefix = '$'
+
+// paramRefRegex matches the params referencing a field eg. '${MaxItems}' or
+// '${Limits.Max}', or the key of the param source eg. '@{limits.name_len}', the
+// braces telling them from literal params such as 'endswith=@example.com'
+var paramRefRegex = regexp.MustCompile(`^(\$\{[A-Za-z_][^\s{}]*\}|@\{[A-Za-z_][A-Za-z0-9_.\-]*\})$`)
+
+// ParamSource returns the value of the key referenced by the params of the tags
+// eg. 'limits.name_len' for 'max=@{limits.name_len}', and whether it has one.
+type ParamSource func(key string) (interface{}, bool)
+
+// ParamMap returns the ParamSource of the values of m, the keys of nested maps
+// being separated by dots eg. 'limits.name_len' for m["limits"]["name_len"].
+func ParamMap(m map[string]interface{}) ParamSource {
+	return func(key string) (interface{}, bool) {
+
+		current := reflect.ValueOf(m)
+
+		for _, k := range strings.Split(key, namespaceSeparator) {
+
+			for current.Kind() == reflect.Interface || current.Kind() == reflect.Ptr {
+				current = current.Elem()
+			}
+
+			if current.Kind() != reflect.Map || current.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+
+			current = current.MapIndex(reflect.ValueOf(k).Convert(current.Type().Key()))
+			if !current.IsValid() {
+				return nil, false
+			}
+		}
+
+		return current.Interface(), true
+	}
+}
+
+// WithParamSource returns a copy of ctx setting the source of the params referencing
+// one eg. 'max=@{limits.name_len}' of the validations it is passed to, such as the
+// limits of a tenant.
+func WithParamSource(ctx context.Context, source ParamSource) context.Context {
+	return context.WithValue(ctx, paramSourceCtxKey, source)
+}
+
+// bindParamRef makes the validation of the tag resolve its param each time it is run
+// when it references a field or the param source, failing when it can't be resolved.
+func bindParamRef(ct *cTag) {
+
+	if !paramRefRegex.MatchString(ct.param) {
+		return
+	}
+
+	ct.paramRef = ct.param
+	fn := ct.fn
+
+	ct.fn = func(ctx context.Context, fl FieldLevel) bool {
+
+		v := fl.(*validate)
+
+		param, ok := v.resolveParam(ctx, ct.paramRef)
+		if !ok {
+			param = ct.paramRef
+		}
+
+		v.paramCt, v.param = ct, param
+
+		return ok && fn(ctx, fl)
+	}
+}
+
+// paramOf returns the param of the tag, resolved when it references a field or
+// the param source.
+func (v *validate) paramOf(ct *cTag) string {
+	if v.paramCt == ct && len(ct.paramRef) > 0 {
+		return v.param
+	}
+	return ct.param
+}
+
+// paramsOf returns the space separated values of the param of the validation eg. those of
+// 'oneof', resolved params not being cached as their values are unbounded.
+func paramsOf(fl FieldLevel) []string {
+
+	if v, ok := fl.(*validate); ok && v.ct != nil && v.paramCt == v.ct && len(v.ct.paramRef) > 0 {
+		return splitOneOfParam(v.param)
+	}
+
+	return parseOneOfParam2(fl.Param())
+}
+
+// paramRefKey returns the namespace of the field or the key of the param source
+// referenced by ref, without its prefix and braces.
+func paramRefKey(ref string) string {
+	return ref[2 : len(ref)-1]
+}
+
+// resolveParam returns the value of the field or key of the param source referenced
+// by ref as it is written in tags, and whether it exists and is not nil.
+func (v *validate) resolveParam(ctx context.Context, ref string) (string, bool) {
+
+	var current reflect.Value
+
+	if ref[0] == fieldParamPrefix {
+
+		var found bool
+
+		current, _, _, found = v.getStructFieldOKInternal(v.slflParent, paramRefKey(ref))
+		if !found {
+			return "", false
+		}
+
+	} else {
+
+		if ctx == nil {
+			return "", false
+		}
+
+		source, ok := ctx.Value(paramSourceCtxKey).(ParamSource)
+		if !ok || source == nil {
+			return "", false
+		}
+
+		val, ok := source(paramRefKey(ref))
+		if !ok || val == nil {
+			return "", false
+		}
+
+		current, _, _ = v.ExtractType(reflect.ValueOf(val))
+	}
+
+	return formatParamValue(current)
+}
+
+// formatParamValue returns the value as it is written in tags, the elements of slices
+// and arrays being separated by spaces and quoted when they contain any eg. for 'oneof'.
+func formatParamValue(current reflect.Value) (string, bool) {
+
+	switch current.Kind() {
+
+	case reflect.Invalid, reflect.Ptr, reflect.Interface:
+		return "", false
+
+	case reflect.String:
+		return current.String(), true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if current.Type() == timeDurationType {
+			return time.Duration(current.Int()).String(), true
+		}
+		return strconv.FormatInt(current.Int(), 10), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(current.Uint(), 10), true
+
+	case reflect.Float32:
+		return strconv.FormatFloat(current.Float(), 'g', -1, 32), true
+
+	case reflect.Float64:
+		return strconv.FormatFloat(current.Float(), 'g', -1, 64), true
+
+	case reflect.Bool:
+		return strconv.FormatBool(current.Bool()), true
+
+	case reflect.Slice, reflect.Array:
+
+		vals := make([]string, 0, current.Len())
+
+		for i := 0; i < current.Len(); i++ {
+
+			elem := current.Index(i)
+			for elem.Kind() == reflect.Interface || elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+
+			s, ok := formatParamValue(elem)
+			if !ok {
+				return "", false
+			}
+
+			if strings.ContainsAny(s, " \t\n") {
+				s = "'" + s + "'"
+			}
+
+			vals = append(vals, s)
+		}
+
+		return strings.Join(vals, " "), true
+
+	case reflect.Struct:
+		if current.Type() == timeType {
+			return current.Interface().(time.Time).Format(time.RFC3339Nano), true
+		}
+	}
+
+	if !current.CanInterface() {
+		return "", false
+	}
+
+	return fmt.Sprint(current.Interface()), true
+}
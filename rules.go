@@ -0,0 +1,211 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	invalidRulesType  = "Rules can only be registered for structs, not '%v'"
+	undefinedRuleFld  = "Rules registered for field '%s' not found in struct '%v'"
+	undefinedRuleType = "rules document type '%s' not found in the types passed in"
+)
+
+// structRule is a rule registered for a struct field, used instead of or
+// along with the field's tag.
+type structRule struct {
+	tag   string
+	merge bool
+}
+
+// apply returns the field's effective tag.
+func (r structRule) apply(tag string) string {
+
+	if !r.merge || len(tag) == 0 || tag == skipValidationTag {
+		return r.tag
+	}
+
+	if len(r.tag) == 0 {
+		return tag
+	}
+
+	return tag + tagSeparator + r.tag
+}
+
+// RegisterStructRules registers the rules of the fields of a struct type, keyed by
+// the fields' names, that are used instead of the fields' tags. This allows
+// validating types that cannot be tagged eg. generated or third party types.
+//
+// eg. validate.RegisterStructRules(User{}, map[string]string{"Email": "required,email"})
+//
+// Rules registered for a type that has already been validated replace its cached
+// tags, which are parsed again on its next validation.
+//
+// NOTES:
+// - panics if s is not a struct or a field is not found within it.
+// - this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterStructRules(s interface{}, rules map[string]string) {
+	v.registerStructRules(s, rules, false)
+}
+
+// MergeStructRules does the same as RegisterStructRules except that the rules are
+// appended to the fields' tags instead of replacing them.
+//
+// NOTES:
+// - panics if s is not a struct or a field is not found within it.
+// - this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) MergeStructRules(s interface{}, rules map[string]string) {
+	v.registerStructRules(s, rules, true)
+}
+
+// RegisterRulesJSON registers the rules of a JSON document for the types passed in,
+// see RegisterRulesDocument.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterRulesJSON(data []byte, types ...interface{}) error {
+	return v.RegisterRulesDocument(data, json.Unmarshal, types...)
+}
+
+// RegisterRulesDocument registers the rules of a document, decoded using unmarshal, for
+// the types passed in. The document maps the types' names, with or without their
+// package name, to their fields' rules as RegisterStructRules. Any unmarshal function
+// decoding into a map[string]map[string]string can be used eg. one from a YAML package:
+//
+//	User:
+//	  Email: required,email
+//	  Name: required,max=64
+//
+//	err := validate.RegisterRulesDocument(data, yaml.Unmarshal, User{})
+//
+// It returns an error if the document cannot be decoded or names a type or field that
+// is not found, in which case no rules are registered.
+//
+// NOTE: this method is not thread-safe it is intended that these all be registered prior to any validation
+func (v *Validate) RegisterRulesDocument(data []byte, unmarshal func([]byte, interface{}) error, types ...interface{}) error {
+
+	var doc map[string]map[string]string
+
+	if err := unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	byName := make(map[string]reflect.Type, len(types)*2)
+
+	for _, t := range types {
+
+		typ, err := rulesType(t)
+		if err != nil {
+			return err
+		}
+
+		byName[typ.Name()] = typ
+		byName[typ.String()] = typ
+	}
+
+	registered := make(map[reflect.Type]map[string]string, len(doc))
+
+	for name, rules := range doc {
+
+		typ, ok := byName[name]
+		if !ok {
+			return fmt.Errorf(undefinedRuleType, name)
+		}
+
+		for fld := range rules {
+			if !hasStructField(typ, fld) {
+				return fmt.Errorf(undefinedRuleFld, fld, typ)
+			}
+		}
+
+		registered[typ] = rules
+	}
+
+	for typ, rules := range registered {
+		v.registerTypeRules(typ, rules, false)
+	}
+
+	return nil
+}
+
+func (v *Validate) registerStructRules(s interface{}, rules map[string]string, merge bool) {
+
+	typ, err := rulesType(s)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for fld := range rules {
+		if !hasStructField(typ, fld) {
+			panic(fmt.Sprintf(undefinedRuleFld, fld, typ))
+		}
+	}
+
+	v.registerTypeRules(typ, rules, merge)
+}
+
+func (v *Validate) registerTypeRules(typ reflect.Type, rules map[string]string, merge bool) {
+
+	if v.structRules == nil {
+		v.structRules = make(map[reflect.Type]map[string]structRule)
+	}
+
+	m, ok := v.structRules[typ]
+	if !ok {
+		m = make(map[string]structRule, len(rules))
+		v.structRules[typ] = m
+	}
+
+	for fld, tag := range rules {
+		m[fld] = structRule{tag: strings.TrimSpace(tag), merge: merge}
+	}
+
+	// the fields' tags are parsed again on the next validation of the type
+	v.structCache.lock.Lock()
+	v.structCache.Delete(typ)
+	v.structCache.lock.Unlock()
+
+	// types having rules are not unwrapped, see wrapperOf
+	v.wrappers.Delete(typ)
+}
+
+// rulesType returns the struct type rules are registered for.
+func rulesType(s interface{}) (reflect.Type, error) {
+
+	typ := reflect.TypeOf(s)
+
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct || typ == timeType {
+		return nil, fmt.Errorf(invalidRulesType, reflect.TypeOf(s))
+	}
+
+	return typ, nil
+}
+
+// hasStructField reports whether name is a field of typ, fields promoted from
+// embedded structs have their rules registered for the embedded type.
+func hasStructField(typ reflect.Type, name string) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldTag returns the validation tag of the field of typ, taking the registered
+// rules into account.
+func (v *Validate) fieldTag(typ reflect.Type, fld reflect.StructField) string {
+
+	tag := fld.Tag.Get(v.tagName)
+
+	if rule, ok := v.structRules[typ][fld.Name]; ok {
+		return rule.apply(tag)
+	}
+
+	return tag
+}
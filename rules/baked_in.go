@@ -0,0 +1,812 @@
+package rules
+
+import (
+	"strings"
+	"time"
+)
+
+// keep in the order of the validator's baked in validations for readability
+
+// Required returns the 'required' rule.
+func Required() Rule {
+	return Rule{tag: "required"}
+}
+
+// IsDefault returns the 'isdefault' rule.
+func IsDefault() Rule {
+	return Rule{tag: "isdefault"}
+}
+
+// OmitEmpty returns the 'omitempty' rule.
+func OmitEmpty() Rule {
+	return Rule{tag: "omitempty"}
+}
+
+// StructOnly returns the 'structonly' rule.
+func StructOnly() Rule {
+	return Rule{tag: "structonly"}
+}
+
+// NoStructLevel returns the 'nostructlevel' rule.
+func NoStructLevel() Rule {
+	return Rule{tag: "nostructlevel"}
+}
+
+// NoValidateMethod returns the 'novalidatemethod' rule.
+func NoValidateMethod() Rule {
+	return Rule{tag: "novalidatemethod"}
+}
+
+// Alpha returns the 'alpha' rule.
+func Alpha() Rule {
+	return Rule{tag: "alpha"}
+}
+
+// AlphaNum returns the 'alphanum' rule.
+func AlphaNum() Rule {
+	return Rule{tag: "alphanum"}
+}
+
+// AlphaUnicode returns the 'alphaunicode' rule.
+func AlphaUnicode() Rule {
+	return Rule{tag: "alphaunicode"}
+}
+
+// AlphaNumUnicode returns the 'alphanumunicode' rule.
+func AlphaNumUnicode() Rule {
+	return Rule{tag: "alphanumunicode"}
+}
+
+// Boolean returns the 'boolean' rule.
+func Boolean() Rule {
+	return Rule{tag: "boolean"}
+}
+
+// Numeric returns the 'numeric' rule.
+func Numeric() Rule {
+	return Rule{tag: "numeric"}
+}
+
+// Number returns the 'number' rule.
+func Number() Rule {
+	return Rule{tag: "number"}
+}
+
+// Hexadecimal returns the 'hexadecimal' rule.
+func Hexadecimal() Rule {
+	return Rule{tag: "hexadecimal"}
+}
+
+// HexColor returns the 'hexcolor' rule.
+func HexColor() Rule {
+	return Rule{tag: "hexcolor"}
+}
+
+// RGB returns the 'rgb' rule.
+func RGB() Rule {
+	return Rule{tag: "rgb"}
+}
+
+// RGBA returns the 'rgba' rule.
+func RGBA() Rule {
+	return Rule{tag: "rgba"}
+}
+
+// HSL returns the 'hsl' rule.
+func HSL() Rule {
+	return Rule{tag: "hsl"}
+}
+
+// HSLA returns the 'hsla' rule.
+func HSLA() Rule {
+	return Rule{tag: "hsla"}
+}
+
+// IsColor returns the 'iscolor' rule.
+func IsColor() Rule {
+	return Rule{tag: "iscolor"}
+}
+
+// E164 returns the 'e164' rule.
+func E164() Rule {
+	return Rule{tag: "e164"}
+}
+
+// Email returns the 'email' rule.
+func Email() Rule {
+	return Rule{tag: "email"}
+}
+
+// URL returns the 'url' rule.
+func URL() Rule {
+	return Rule{tag: "url"}
+}
+
+// URI returns the 'uri' rule.
+func URI() Rule {
+	return Rule{tag: "uri"}
+}
+
+// URNRFC2141 returns the 'urn_rfc2141' rule.
+func URNRFC2141() Rule {
+	return Rule{tag: "urn_rfc2141"}
+}
+
+// File returns the 'file' rule.
+func File() Rule {
+	return Rule{tag: "file"}
+}
+
+// Dir returns the 'dir' rule.
+func Dir() Rule {
+	return Rule{tag: "dir"}
+}
+
+// Base64 returns the 'base64' rule.
+func Base64() Rule {
+	return Rule{tag: "base64"}
+}
+
+// Base64URL returns the 'base64url' rule.
+func Base64URL() Rule {
+	return Rule{tag: "base64url"}
+}
+
+// ISBN returns the 'isbn' rule.
+func ISBN() Rule {
+	return Rule{tag: "isbn"}
+}
+
+// ISBN10 returns the 'isbn10' rule.
+func ISBN10() Rule {
+	return Rule{tag: "isbn10"}
+}
+
+// ISBN13 returns the 'isbn13' rule.
+func ISBN13() Rule {
+	return Rule{tag: "isbn13"}
+}
+
+// EthAddr returns the 'eth_addr' rule.
+func EthAddr() Rule {
+	return Rule{tag: "eth_addr"}
+}
+
+// BtcAddr returns the 'btc_addr' rule.
+func BtcAddr() Rule {
+	return Rule{tag: "btc_addr"}
+}
+
+// BtcAddrBech32 returns the 'btc_addr_bech32' rule.
+func BtcAddrBech32() Rule {
+	return Rule{tag: "btc_addr_bech32"}
+}
+
+// UUID returns the 'uuid' rule.
+func UUID() Rule {
+	return Rule{tag: "uuid"}
+}
+
+// UUID3 returns the 'uuid3' rule.
+func UUID3() Rule {
+	return Rule{tag: "uuid3"}
+}
+
+// UUID4 returns the 'uuid4' rule.
+func UUID4() Rule {
+	return Rule{tag: "uuid4"}
+}
+
+// UUID5 returns the 'uuid5' rule.
+func UUID5() Rule {
+	return Rule{tag: "uuid5"}
+}
+
+// UUIDRFC4122 returns the 'uuid_rfc4122' rule.
+func UUIDRFC4122() Rule {
+	return Rule{tag: "uuid_rfc4122"}
+}
+
+// UUID3RFC4122 returns the 'uuid3_rfc4122' rule.
+func UUID3RFC4122() Rule {
+	return Rule{tag: "uuid3_rfc4122"}
+}
+
+// UUID4RFC4122 returns the 'uuid4_rfc4122' rule.
+func UUID4RFC4122() Rule {
+	return Rule{tag: "uuid4_rfc4122"}
+}
+
+// UUID5RFC4122 returns the 'uuid5_rfc4122' rule.
+func UUID5RFC4122() Rule {
+	return Rule{tag: "uuid5_rfc4122"}
+}
+
+// ASCII returns the 'ascii' rule.
+func ASCII() Rule {
+	return Rule{tag: "ascii"}
+}
+
+// PrintASCII returns the 'printascii' rule.
+func PrintASCII() Rule {
+	return Rule{tag: "printascii"}
+}
+
+// Multibyte returns the 'multibyte' rule.
+func Multibyte() Rule {
+	return Rule{tag: "multibyte"}
+}
+
+// DataURI returns the 'datauri' rule.
+func DataURI() Rule {
+	return Rule{tag: "datauri"}
+}
+
+// Latitude returns the 'latitude' rule.
+func Latitude() Rule {
+	return Rule{tag: "latitude"}
+}
+
+// Longitude returns the 'longitude' rule.
+func Longitude() Rule {
+	return Rule{tag: "longitude"}
+}
+
+// SSN returns the 'ssn' rule.
+func SSN() Rule {
+	return Rule{tag: "ssn"}
+}
+
+// IPv4 returns the 'ipv4' rule.
+func IPv4() Rule {
+	return Rule{tag: "ipv4"}
+}
+
+// IPv6 returns the 'ipv6' rule.
+func IPv6() Rule {
+	return Rule{tag: "ipv6"}
+}
+
+// IP returns the 'ip' rule.
+func IP() Rule {
+	return Rule{tag: "ip"}
+}
+
+// CIDRv4 returns the 'cidrv4' rule.
+func CIDRv4() Rule {
+	return Rule{tag: "cidrv4"}
+}
+
+// CIDRv6 returns the 'cidrv6' rule.
+func CIDRv6() Rule {
+	return Rule{tag: "cidrv6"}
+}
+
+// CIDR returns the 'cidr' rule.
+func CIDR() Rule {
+	return Rule{tag: "cidr"}
+}
+
+// TCP4Addr returns the 'tcp4_addr' rule.
+func TCP4Addr() Rule {
+	return Rule{tag: "tcp4_addr"}
+}
+
+// TCP6Addr returns the 'tcp6_addr' rule.
+func TCP6Addr() Rule {
+	return Rule{tag: "tcp6_addr"}
+}
+
+// TCPAddr returns the 'tcp_addr' rule.
+func TCPAddr() Rule {
+	return Rule{tag: "tcp_addr"}
+}
+
+// UDP4Addr returns the 'udp4_addr' rule.
+func UDP4Addr() Rule {
+	return Rule{tag: "udp4_addr"}
+}
+
+// UDP6Addr returns the 'udp6_addr' rule.
+func UDP6Addr() Rule {
+	return Rule{tag: "udp6_addr"}
+}
+
+// UDPAddr returns the 'udp_addr' rule.
+func UDPAddr() Rule {
+	return Rule{tag: "udp_addr"}
+}
+
+// IP4Addr returns the 'ip4_addr' rule.
+func IP4Addr() Rule {
+	return Rule{tag: "ip4_addr"}
+}
+
+// IP6Addr returns the 'ip6_addr' rule.
+func IP6Addr() Rule {
+	return Rule{tag: "ip6_addr"}
+}
+
+// IPAddr returns the 'ip_addr' rule.
+func IPAddr() Rule {
+	return Rule{tag: "ip_addr"}
+}
+
+// UnixAddr returns the 'unix_addr' rule.
+func UnixAddr() Rule {
+	return Rule{tag: "unix_addr"}
+}
+
+// MAC returns the 'mac' rule.
+func MAC() Rule {
+	return Rule{tag: "mac"}
+}
+
+// Hostname returns the 'hostname' rule.
+func Hostname() Rule {
+	return Rule{tag: "hostname"}
+}
+
+// HostnameRFC1123 returns the 'hostname_rfc1123' rule.
+func HostnameRFC1123() Rule {
+	return Rule{tag: "hostname_rfc1123"}
+}
+
+// HostnamePort returns the 'hostname_port' rule.
+func HostnamePort() Rule {
+	return Rule{tag: "hostname_port"}
+}
+
+// FQDN returns the 'fqdn' rule.
+func FQDN() Rule {
+	return Rule{tag: "fqdn"}
+}
+
+// HTML returns the 'html' rule.
+func HTML() Rule {
+	return Rule{tag: "html"}
+}
+
+// HTMLEncoded returns the 'html_encoded' rule.
+func HTMLEncoded() Rule {
+	return Rule{tag: "html_encoded"}
+}
+
+// URLEncoded returns the 'url_encoded' rule.
+func URLEncoded() Rule {
+	return Rule{tag: "url_encoded"}
+}
+
+// JSON returns the 'json' rule.
+func JSON() Rule {
+	return Rule{tag: "json"}
+}
+
+// JWT returns the 'jwt' rule.
+func JWT() Rule {
+	return Rule{tag: "jwt"}
+}
+
+// Lowercase returns the 'lowercase' rule.
+func Lowercase() Rule {
+	return Rule{tag: "lowercase"}
+}
+
+// Uppercase returns the 'uppercase' rule.
+func Uppercase() Rule {
+	return Rule{tag: "uppercase"}
+}
+
+// Timezone returns the 'timezone' rule.
+func Timezone() Rule {
+	return Rule{tag: "timezone"}
+}
+
+// ISO3166Alpha2 returns the 'iso3166_1_alpha2' rule.
+func ISO3166Alpha2() Rule {
+	return Rule{tag: "iso3166_1_alpha2"}
+}
+
+// ISO3166Alpha3 returns the 'iso3166_1_alpha3' rule.
+func ISO3166Alpha3() Rule {
+	return Rule{tag: "iso3166_1_alpha3"}
+}
+
+// ISO3166AlphaNumeric returns the 'iso3166_1_alpha_numeric' rule.
+func ISO3166AlphaNumeric() Rule {
+	return Rule{tag: "iso3166_1_alpha_numeric"}
+}
+
+// ISO3166Subdivision returns the 'iso3166_2' rule.
+func ISO3166Subdivision() Rule {
+	return Rule{tag: "iso3166_2"}
+}
+
+// CountryCode returns the 'country_code' rule.
+func CountryCode() Rule {
+	return Rule{tag: "country_code"}
+}
+
+// ISO4217 returns the 'iso4217' rule.
+func ISO4217() Rule {
+	return Rule{tag: "iso4217"}
+}
+
+// ISO4217Numeric returns the 'iso4217_numeric' rule.
+func ISO4217Numeric() Rule {
+	return Rule{tag: "iso4217_numeric"}
+}
+
+// BCP47LanguageTag returns the 'bcp47_language_tag' rule.
+func BCP47LanguageTag() Rule {
+	return Rule{tag: "bcp47_language_tag"}
+}
+
+// BIC returns the 'bic' rule.
+func BIC() Rule {
+	return Rule{tag: "bic"}
+}
+
+// RequiredIf returns the 'required_if' rule of the field and value pairs eg. RequiredIf("Kind", "card").
+func RequiredIf(fieldValuePairs ...string) Rule {
+	return Tag("required_if", fieldValues(fieldValuePairs)...)
+}
+
+// RequiredUnless returns the 'required_unless' rule of the field and value pairs eg. RequiredIf("Kind", "card").
+func RequiredUnless(fieldValuePairs ...string) Rule {
+	return Tag("required_unless", fieldValues(fieldValuePairs)...)
+}
+
+// ExcludedIf returns the 'excluded_if' rule of the field and value pairs eg. RequiredIf("Kind", "card").
+func ExcludedIf(fieldValuePairs ...string) Rule {
+	return Tag("excluded_if", fieldValues(fieldValuePairs)...)
+}
+
+// ExcludedUnless returns the 'excluded_unless' rule of the field and value pairs eg. RequiredIf("Kind", "card").
+func ExcludedUnless(fieldValuePairs ...string) Rule {
+	return Tag("excluded_unless", fieldValues(fieldValuePairs)...)
+}
+
+// RequiredWhen returns the 'required_when' rule of the condition expression.
+func RequiredWhen(expr string) Rule {
+	return Rule{tag: "required_when=" + formatParam(expr)}
+}
+
+// ExcludedWhen returns the 'excluded_when' rule of the condition expression.
+func ExcludedWhen(expr string) Rule {
+	return Rule{tag: "excluded_when=" + formatParam(expr)}
+}
+
+// RequiredWith returns the 'required_with' rule of the fields.
+func RequiredWith(fields ...string) Rule {
+	return Tag("required_with", fieldValues(fields)...)
+}
+
+// RequiredWithAll returns the 'required_with_all' rule of the fields.
+func RequiredWithAll(fields ...string) Rule {
+	return Tag("required_with_all", fieldValues(fields)...)
+}
+
+// RequiredWithout returns the 'required_without' rule of the fields.
+func RequiredWithout(fields ...string) Rule {
+	return Tag("required_without", fieldValues(fields)...)
+}
+
+// RequiredWithoutAll returns the 'required_without_all' rule of the fields.
+func RequiredWithoutAll(fields ...string) Rule {
+	return Tag("required_without_all", fieldValues(fields)...)
+}
+
+// ExcludedWith returns the 'excluded_with' rule of the fields.
+func ExcludedWith(fields ...string) Rule {
+	return Tag("excluded_with", fieldValues(fields)...)
+}
+
+// ExcludedWithAll returns the 'excluded_with_all' rule of the fields.
+func ExcludedWithAll(fields ...string) Rule {
+	return Tag("excluded_with_all", fieldValues(fields)...)
+}
+
+// ExcludedWithout returns the 'excluded_without' rule of the fields.
+func ExcludedWithout(fields ...string) Rule {
+	return Tag("excluded_without", fieldValues(fields)...)
+}
+
+// ExcludedWithoutAll returns the 'excluded_without_all' rule of the fields.
+func ExcludedWithoutAll(fields ...string) Rule {
+	return Tag("excluded_without_all", fieldValues(fields)...)
+}
+
+// Len returns the 'len' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Len(param interface{}) Rule {
+	return Tag("len", param)
+}
+
+// Min returns the 'min' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Min(param interface{}) Rule {
+	return Tag("min", param)
+}
+
+// Max returns the 'max' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Max(param interface{}) Rule {
+	return Tag("max", param)
+}
+
+// Eq returns the 'eq' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Eq(param interface{}) Rule {
+	return Tag("eq", param)
+}
+
+// Ne returns the 'ne' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Ne(param interface{}) Rule {
+	return Tag("ne", param)
+}
+
+// Lt returns the 'lt' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Lt(param interface{}) Rule {
+	return Tag("lt", param)
+}
+
+// Lte returns the 'lte' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Lte(param interface{}) Rule {
+	return Tag("lte", param)
+}
+
+// Gt returns the 'gt' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Gt(param interface{}) Rule {
+	return Tag("gt", param)
+}
+
+// Gte returns the 'gte' rule, the param is a number, string, bool or time.Duration
+// depending on the field.
+func Gte(param interface{}) Rule {
+	return Tag("gte", param)
+}
+
+// EqField returns the 'eqfield' rule of the field.
+func EqField(field string) Rule {
+	return Tag("eqfield", field)
+}
+
+// EqCSField returns the 'eqcsfield' rule of the field.
+func EqCSField(field string) Rule {
+	return Tag("eqcsfield", field)
+}
+
+// NeCSField returns the 'necsfield' rule of the field.
+func NeCSField(field string) Rule {
+	return Tag("necsfield", field)
+}
+
+// GtCSField returns the 'gtcsfield' rule of the field.
+func GtCSField(field string) Rule {
+	return Tag("gtcsfield", field)
+}
+
+// GteCSField returns the 'gtecsfield' rule of the field.
+func GteCSField(field string) Rule {
+	return Tag("gtecsfield", field)
+}
+
+// LtCSField returns the 'ltcsfield' rule of the field.
+func LtCSField(field string) Rule {
+	return Tag("ltcsfield", field)
+}
+
+// LteCSField returns the 'ltecsfield' rule of the field.
+func LteCSField(field string) Rule {
+	return Tag("ltecsfield", field)
+}
+
+// NeField returns the 'nefield' rule of the field.
+func NeField(field string) Rule {
+	return Tag("nefield", field)
+}
+
+// GteField returns the 'gtefield' rule of the field.
+func GteField(field string) Rule {
+	return Tag("gtefield", field)
+}
+
+// GtField returns the 'gtfield' rule of the field.
+func GtField(field string) Rule {
+	return Tag("gtfield", field)
+}
+
+// LteField returns the 'ltefield' rule of the field.
+func LteField(field string) Rule {
+	return Tag("ltefield", field)
+}
+
+// LtField returns the 'ltfield' rule of the field.
+func LtField(field string) Rule {
+	return Tag("ltfield", field)
+}
+
+// FieldContains returns the 'fieldcontains' rule of the field.
+func FieldContains(field string) Rule {
+	return Tag("fieldcontains", field)
+}
+
+// FieldExcludes returns the 'fieldexcludes' rule of the field.
+func FieldExcludes(field string) Rule {
+	return Tag("fieldexcludes", field)
+}
+
+// PostcodeISO3166Alpha2Field returns the 'postcode_iso3166_alpha2_field' rule of the field.
+func PostcodeISO3166Alpha2Field(field string) Rule {
+	return Tag("postcode_iso3166_alpha2_field", field)
+}
+
+// Contains returns the 'contains' rule.
+func Contains(s string) Rule {
+	return Tag("contains", s)
+}
+
+// ContainsAny returns the 'containsany' rule.
+func ContainsAny(chars string) Rule {
+	return Tag("containsany", chars)
+}
+
+// Excludes returns the 'excludes' rule.
+func Excludes(s string) Rule {
+	return Tag("excludes", s)
+}
+
+// ExcludesAll returns the 'excludesall' rule.
+func ExcludesAll(chars string) Rule {
+	return Tag("excludesall", chars)
+}
+
+// StartsWith returns the 'startswith' rule.
+func StartsWith(prefix string) Rule {
+	return Tag("startswith", prefix)
+}
+
+// EndsWith returns the 'endswith' rule.
+func EndsWith(suffix string) Rule {
+	return Tag("endswith", suffix)
+}
+
+// StartsNotWith returns the 'startsnotwith' rule.
+func StartsNotWith(prefix string) Rule {
+	return Tag("startsnotwith", prefix)
+}
+
+// EndsNotWith returns the 'endsnotwith' rule.
+func EndsNotWith(suffix string) Rule {
+	return Tag("endsnotwith", suffix)
+}
+
+// ContainsRune returns the 'containsrune' rule.
+func ContainsRune(r rune) Rule {
+	return Tag("containsrune", string(r))
+}
+
+// ExcludesRune returns the 'excludesrune' rule.
+func ExcludesRune(r rune) Rule {
+	return Tag("excludesrune", string(r))
+}
+
+// Datetime returns the 'datetime' rule of the time.Parse layout.
+func Datetime(layout string) Rule {
+	return Tag("datetime", layout)
+}
+
+// Before returns the 'before' rule, the param is a time.Time or a string of an
+// RFC 3339 time, a date or a time relative to now eg. "now+24h".
+func Before(param interface{}) Rule {
+	return Tag("before", param)
+}
+
+// After returns the 'after' rule, the param is a time.Time or a string of an
+// RFC 3339 time, a date or a time relative to now eg. "now-24h".
+func After(param interface{}) Rule {
+	return Tag("after", param)
+}
+
+// Future returns the 'future' rule.
+func Future() Rule {
+	return Rule{tag: "future"}
+}
+
+// Past returns the 'past' rule.
+func Past() Rule {
+	return Rule{tag: "past"}
+}
+
+// Within returns the 'within' rule of the duration.
+func Within(d time.Duration) Rule {
+	return Tag("within", d)
+}
+
+// Weekday returns the 'weekday' rule of the days or ranges of days eg. "mon-fri".
+func Weekday(days ...string) Rule {
+	return Tag("weekday", fieldValues(days)...)
+}
+
+// DateOnly returns the 'date_only' rule.
+func DateOnly() Rule {
+	return Rule{tag: "date_only"}
+}
+
+// TimezoneIs returns the 'timezone_is' rule of the time zone name.
+func TimezoneIs(name string) Rule {
+	return Tag("timezone_is", name)
+}
+
+// Decimal returns the 'decimal' rule of the precision and scale, the total number of
+// digits and the number of digits after the decimal point.
+func Decimal(precision int, scale int) Rule {
+	return Tag("decimal", precision, scale)
+}
+
+// MultipleOf returns the 'multiple_of' rule, the param is a number, a *big.Int, a
+// *big.Float or a numeric string.
+func MultipleOf(param interface{}) Rule {
+	return Tag("multiple_of", param)
+}
+
+// MinNum returns the 'min_num' rule, the param is a number, a *big.Int, a *big.Float
+// or a numeric string.
+func MinNum(param interface{}) Rule {
+	return Tag("min_num", param)
+}
+
+// MaxNum returns the 'max_num' rule, the param is a number, a *big.Int, a *big.Float
+// or a numeric string.
+func MaxNum(param interface{}) Rule {
+	return Tag("max_num", param)
+}
+
+// GtNum returns the 'gt_num' rule, the param is a number, a *big.Int, a *big.Float
+// or a numeric string.
+func GtNum(param interface{}) Rule {
+	return Tag("gt_num", param)
+}
+
+// LtNum returns the 'lt_num' rule, the param is a number, a *big.Int, a *big.Float
+// or a numeric string.
+func LtNum(param interface{}) Rule {
+	return Tag("lt_num", param)
+}
+
+// PostcodeISO3166Alpha2 returns the 'postcode_iso3166_alpha2' rule of the country code.
+func PostcodeISO3166Alpha2(country string) Rule {
+	return Tag("postcode_iso3166_alpha2", country)
+}
+
+// Method returns the 'method' rule calling the method of the struct containing the
+// field, or of the field's type.
+func Method(name string) Rule {
+	return Tag("method", name)
+}
+
+// Unique returns the 'unique' rule, of the field of the elements when they are structs.
+func Unique(field ...string) Rule {
+	return Tag("unique", fieldValues(field)...)
+}
+
+// OneOf returns the 'oneof' rule of the values, values containing spaces are quoted.
+func OneOf(values ...interface{}) Rule {
+
+	params := make([]interface{}, len(values))
+
+	for i, v := range values {
+		params[i] = v
+
+		if s, ok := v.(string); ok && strings.ContainsAny(s, " \t\n") {
+			params[i] = "'" + s + "'"
+		}
+	}
+
+	return Tag("oneof", params...)
+}
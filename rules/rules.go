@@ -0,0 +1,181 @@
+/*
+Package rules provides typed constructors of validation rules, used with the
+validator's For builder as an alternative to writing struct tags by hand.
+
+	validate.For(&User{}).
+		Field("Name", rules.Required(), rules.Min(3)).
+		Field("Emails", rules.Dive(rules.Email()))
+
+Each rule renders to the tag it stands for, so misspelled rule names fail at
+compile time while the validation itself is the same as that of the tag. Custom
+validations and aliases, which have no constructor, are used with Tag.
+
+	rules.Tag("is-awesome")
+	rules.Tag("range", 1, 10)
+*/
+package rules
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	tagSeparator   = ","
+	orSeparator    = "|"
+	paramSeparator = " "
+	utf8HexComma   = "0x2C"
+	utf8Pipe       = "0x7C"
+	skipTag        = "-"
+	warnTag        = "warn"
+	diveTag        = "dive"
+	keysTag        = "keys"
+	endKeysTag     = "endkeys"
+)
+
+// Rule is a validation rule, its String method returns the tag it stands for.
+type Rule struct {
+	tag string
+}
+
+// String returns the tag of the rule.
+func (r Rule) String() string {
+	return r.tag
+}
+
+// Tag returns the rule of the validation tag name, with the params, if any,
+// separated by spaces. It is used for custom validations and aliases.
+//
+// Commas and pipes within params are escaped, so they are never taken for
+// the separators of the tag.
+func Tag(name string, params ...interface{}) Rule {
+
+	if len(params) == 0 {
+		return Rule{tag: name}
+	}
+
+	vals := make([]string, len(params))
+
+	for i, p := range params {
+		vals[i] = formatParam(p)
+	}
+
+	return Rule{tag: name + "=" + strings.Join(vals, paramSeparator)}
+}
+
+// Ref is a param referencing a field or a key of the param source of the validation,
+// resolved each time the rule is validated.
+type Ref string
+
+// FieldRef returns the param referencing the field of the namespace, within the struct
+// containing the validated field eg. rules.Max(rules.FieldRef("MaxItems")).
+func FieldRef(namespace string) Ref {
+	return Ref("${" + namespace + "}")
+}
+
+// SourceRef returns the param referencing the key of the param source set using
+// validator.WithParamSource eg. rules.Max(rules.SourceRef("limits.name_len")).
+func SourceRef(key string) Ref {
+	return Ref("@{" + key + "}")
+}
+
+// Join returns the tag of the rules, in order.
+func Join(rules ...Rule) string {
+	return join(rules, tagSeparator)
+}
+
+// Skip returns the '-' rule, skipping the field.
+func Skip() Rule {
+	return Rule{tag: skipTag}
+}
+
+// Dive returns the 'dive' rule followed by the rules of the elements of
+// slices, arrays and maps, which may in turn dive.
+//
+//	rules.Dive(rules.Required(), rules.Dive(rules.Email()))
+func Dive(rules ...Rule) Rule {
+
+	if len(rules) == 0 {
+		return Rule{tag: diveTag}
+	}
+
+	return Rule{tag: diveTag + tagSeparator + join(rules, tagSeparator)}
+}
+
+// Keys returns the rules of the keys of a map, within 'keys' and 'endkeys',
+// it must be the first rule of Dive.
+//
+//	rules.Dive(rules.Keys(rules.Min(2)), rules.Required())
+func Keys(rules ...Rule) Rule {
+	return Rule{tag: keysTag + tagSeparator + join(rules, tagSeparator) + tagSeparator + endKeysTag}
+}
+
+// Warn returns the rules within 'warn', whose failures are warnings that do not
+// fail the validation.
+func Warn(rules ...Rule) Rule {
+	return Rule{tag: warnTag + "(" + join(rules, tagSeparator) + ")"}
+}
+
+// Or returns the rules separated by '|', passing when any of them passes.
+func Or(rules ...Rule) Rule {
+	return Rule{tag: join(rules, orSeparator)}
+}
+
+// Group returns the rules within parentheses, all of which must pass, eg. to use
+// them within Or or Not.
+//
+//	rules.Or(rules.StartsWith("A"), rules.Group(rules.Len(3), rules.Numeric()))
+func Group(rules ...Rule) Rule {
+	return Rule{tag: "(" + join(rules, tagSeparator) + ")"}
+}
+
+// Not returns the negation of the rule, passing when it fails.
+func Not(rule Rule) Rule {
+	return Rule{tag: "!" + rule.tag}
+}
+
+func join(rules []Rule, sep string) string {
+
+	tags := make([]string, len(rules))
+
+	for i, r := range rules {
+		tags[i] = r.tag
+	}
+
+	return strings.Join(tags, sep)
+}
+
+// formatParam returns the param as it is written in tags.
+func formatParam(param interface{}) string {
+
+	var s string
+
+	switch p := param.(type) {
+	case string:
+		s = p
+	case time.Duration:
+		s = p.String()
+	case time.Time:
+		s = p.Format(time.RFC3339Nano)
+	case *big.Float:
+		s = p.Text('g', -1)
+	default:
+		s = fmt.Sprint(p)
+	}
+
+	return strings.NewReplacer(tagSeparator, utf8HexComma, orSeparator, utf8Pipe).Replace(s)
+}
+
+// fieldValues returns the params of field and value pairs.
+func fieldValues(fieldValuePairs []string) []interface{} {
+
+	params := make([]interface{}, len(fieldValuePairs))
+
+	for i, p := range fieldValuePairs {
+		params[i] = p
+	}
+
+	return params
+}
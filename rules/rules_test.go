@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/haiyiyun/validator/assert"
+)
+
+func TestRules(t *testing.T) {
+
+	tests := []struct {
+		rule     Rule
+		expected string
+	}{
+		{rule: Required(), expected: "required"},
+		{rule: Min(3), expected: "min=3"},
+		{rule: Max(1.5), expected: "max=1.5"},
+		{rule: Max(time.Minute), expected: "max=1m0s"},
+		{rule: Eq(true), expected: "eq=true"},
+		{rule: Contains("a,b|c"), expected: "contains=a0x2Cb0x7Cc"},
+		{rule: ContainsRune('@'), expected: "containsrune=@"},
+		{rule: OneOf("red", "dark blue", 3), expected: "oneof=red 'dark blue' 3"},
+		{rule: RequiredIf("Kind", "card", "Country", "FR"), expected: "required_if=Kind card Country FR"},
+		{rule: RequiredWith("Email", "Phone"), expected: "required_with=Email Phone"},
+		{rule: RequiredWhen("Kind == 'a' || Kind == 'b'"), expected: "required_when=Kind == 'a' 0x7C0x7C Kind == 'b'"},
+		{rule: Unique(), expected: "unique"},
+		{rule: Unique("ID"), expected: "unique=ID"},
+		{rule: EqField("Password"), expected: "eqfield=Password"},
+		{rule: Dive(), expected: "dive"},
+		{rule: Dive(Required(), Dive(Email())), expected: "dive,required,dive,email"},
+		{rule: Dive(Keys(Min(2), Alpha()), Required()), expected: "dive,keys,min=2,alpha,endkeys,required"},
+		{rule: Or(RGB(), RGBA(), HexColor()), expected: "rgb|rgba|hexcolor"},
+		{rule: Warn(Min(12), ContainsAny("!@#")), expected: "warn(min=12,containsany=!@#)"},
+		{rule: Tag("is-awesome"), expected: "is-awesome"},
+		{rule: Tag("range", 1, 10), expected: "range=1 10"},
+		{rule: Skip(), expected: "-"},
+		{rule: Or(StartsWith("A"), Group(Len(3), Numeric())), expected: "startswith=A|(len=3,numeric)"},
+		{rule: Not(Contains("test")), expected: "!contains=test"},
+		{rule: Not(Group(Or(Alpha(), Numeric()))), expected: "!(alpha|numeric)"},
+		{rule: After("now-24h"), expected: "after=now-24h"},
+		{rule: Before(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)), expected: "before=2024-04-01T00:00:00Z"},
+		{rule: Within(72 * time.Hour), expected: "within=72h0m0s"},
+		{rule: Weekday("mon-fri", "sun"), expected: "weekday=mon-fri sun"},
+		{rule: TimezoneIs("Europe/Paris"), expected: "timezone_is=Europe/Paris"},
+		{rule: Decimal(10, 2), expected: "decimal=10 2"},
+		{rule: MultipleOf(0.05), expected: "multiple_of=0.05"},
+		{rule: MinNum("0.01"), expected: "min_num=0.01"},
+		{rule: MaxNum(new(big.Int).Lsh(big.NewInt(1), 64)), expected: "max_num=18446744073709551616"},
+		{rule: GtNum(big.NewFloat(1.000000000001)), expected: "gt_num=1.000000000001"},
+		{rule: LtNum(100), expected: "lt_num=100"},
+		{rule: Max(FieldRef("MaxItems")), expected: "max=${MaxItems}"},
+		{rule: Method("CheckSKU"), expected: "method=CheckSKU"},
+		{rule: OneOf(FieldRef("Plan.Allowed")), expected: "oneof=${Plan.Allowed}"},
+		{rule: Max(SourceRef("limits.name_len")), expected: "max=@{limits.name_len}"},
+	}
+
+	for i, test := range tests {
+		if test.rule.String() != test.expected {
+			t.Fatalf("Index: %d Rule: %s Expected: %s", i, test.rule, test.expected)
+		}
+	}
+
+	Equal(t, Join(OmitEmpty(), Min(1), Max(10)), "omitempty,min=1,max=10")
+	Equal(t, Join(), "")
+}
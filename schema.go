@@ -0,0 +1,701 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	jsonSchemaDraft       = "https://json-schema.org/draft/2020-12/schema"
+	jsonSchemaDefsPrefix  = "#/$defs/"
+	schemaTypeNull        = "null"
+	schemaTypeBoolean     = "boolean"
+	schemaTypeInteger     = "integer"
+	schemaTypeNumber      = "number"
+	schemaTypeString      = "string"
+	schemaTypeArray       = "array"
+	schemaTypeObject      = "object"
+	schemaFormatDateTime  = "date-time"
+	schemaEncodingBase64  = "base64"
+	schemaUnsupportedJoin = "|"
+)
+
+// SchemaType holds the JSON Schema 'type' keyword. It is marshalled as a
+// single string when it contains only one type and as an array otherwise.
+type SchemaType []string
+
+// MarshalJSON implements json.Marshaler
+func (st SchemaType) MarshalJSON() ([]byte, error) {
+	if len(st) == 1 {
+		return json.Marshal(st[0])
+	}
+	return json.Marshal([]string(st))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (st *SchemaType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*st = SchemaType{s}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(st))
+}
+
+// Schema is a JSON Schema (Draft 2020-12) document generated from the
+// validation tags of a struct. Only the keywords the generator can produce
+// are modelled.
+//
+// Unsupported lists the validation tags found on the field that cannot be
+// expressed as JSON Schema keywords, eg. cross field validations like
+// 'eqfield' or custom registered validations, it is marshalled using the
+// 'x-unsupported-tags' extension keyword.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	Minimum              json.Number        `json:"minimum,omitempty"`
+	Maximum              json.Number        `json:"maximum,omitempty"`
+	ExclusiveMinimum     json.Number        `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     json.Number        `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int64             `json:"minItems,omitempty"`
+	MaxItems             *int64             `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinProperties        *int64             `json:"minProperties,omitempty"`
+	MaxProperties        *int64             `json:"maxProperties,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	Unsupported          []string           `json:"x-unsupported-tags,omitempty"`
+}
+
+// JSONSchema generates a Draft 2020-12 JSON Schema for the struct type of s
+// from the same cached tag information used by Struct.
+//
+// Nested named struct types are emitted once in '$defs' and referenced using
+// '$ref'; property names honour the function registered with
+// RegisterTagNameFunc. Tags that cannot be expressed are listed per property
+// in Schema.Unsupported.
+//
+// It returns InvalidValidationError for bad values passed in.
+func (v *Validate) JSONSchema(s interface{}) (*Schema, error) {
+
+	typ := reflect.TypeOf(s)
+
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct || typ == timeType {
+		return nil, &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	g := newSchemaGenerator(v, jsonSchemaDefsPrefix)
+
+	root := *g.structSchema(typ)
+	root.Schema = jsonSchemaDraft
+	root.Title = typ.Name()
+
+	// the root type is the document itself and does not need a definition,
+	// unless it is self referencing.
+	if name, ok := g.names[typ]; ok && !g.referenced[name] {
+		delete(g.defs, name)
+	}
+
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+
+	return &root, nil
+}
+
+// schemaGenerator walks the struct cache and builds Schema's from it,
+// collecting the named struct types it encounters as definitions.
+type schemaGenerator struct {
+	v          *Validate
+	refPrefix  string
+	defs       map[string]*Schema
+	names      map[reflect.Type]string
+	referenced map[string]bool
+}
+
+func newSchemaGenerator(v *Validate, refPrefix string) *schemaGenerator {
+	return &schemaGenerator{
+		v:          v,
+		refPrefix:  refPrefix,
+		defs:       make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+		referenced: make(map[string]bool),
+	}
+}
+
+// defName returns a unique definition name for the named struct type typ.
+func (g *schemaGenerator) defName(typ reflect.Type) string {
+
+	if name, ok := g.names[typ]; ok {
+		return name
+	}
+
+	name := typ.Name()
+
+	if _, ok := g.defs[name]; ok {
+		// different type with the same name from another package
+		name = strings.NewReplacer("/", "_", ".", "_").Replace(typ.PkgPath()) + "_" + name
+	}
+
+	g.names[typ] = name
+	return name
+}
+
+// structSchema returns the object schema of the struct type typ, the type
+// is registered as a definition before its fields are visited so that
+// recursive types resolve to a '$ref'.
+func (g *schemaGenerator) structSchema(typ reflect.Type) *Schema {
+
+	s := &Schema{Type: SchemaType{schemaTypeObject}, Properties: make(map[string]*Schema)}
+
+	if typ.Name() != "" {
+		name := g.defName(typ)
+		if existing, ok := g.defs[name]; ok {
+			return existing
+		}
+		g.defs[name] = s
+	}
+
+	cs, ok := g.v.structCache.Get(typ)
+	if !ok {
+		cs = g.v.extractStructCache(reflect.New(typ).Elem(), typ.Name())
+	}
+
+	var f *cField
+	var fs *Schema
+	var required bool
+
+	for i := 0; i < len(cs.fields); i++ {
+
+		f = cs.fields[i]
+		fld := typ.Field(f.idx)
+
+		fs, required = g.fieldSchema(fld.Type, f.cTags)
+
+		if fld.Anonymous && f.namesEqual && !f.cTags.hasTag && fs.Ref != "" {
+			// embedded structs have their fields promoted by encoding/json
+			s.AllOf = append(s.AllOf, fs)
+			continue
+		}
+
+		s.Properties[f.altName] = fs
+
+		if required {
+			s.Required = append(s.Required, f.altName)
+		}
+	}
+
+	return s
+}
+
+// typeSchema returns the schema describing the Go type typ without any of
+// the validation rules applied.
+func (g *schemaGenerator) typeSchema(typ reflect.Type) *Schema {
+
+	switch typ.Kind() {
+
+	case reflect.Bool:
+		return &Schema{Type: SchemaType{schemaTypeBoolean}}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: SchemaType{schemaTypeInteger}}
+
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: SchemaType{schemaTypeNumber}}
+
+	case reflect.String:
+		return &Schema{Type: SchemaType{schemaTypeString}}
+
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: SchemaType{schemaTypeString}, ContentEncoding: schemaEncodingBase64}
+		}
+		return &Schema{Type: SchemaType{schemaTypeArray}, Items: g.typeSchema(derefType(typ.Elem()))}
+
+	case reflect.Map:
+		return &Schema{Type: SchemaType{schemaTypeObject}, AdditionalProperties: g.typeSchema(derefType(typ.Elem()))}
+
+	case reflect.Struct:
+
+		if typ == timeType {
+			return &Schema{Type: SchemaType{schemaTypeString}, Format: schemaFormatDateTime}
+		}
+
+		if typ.Name() == "" {
+			return g.structSchema(typ)
+		}
+
+		name := g.defName(typ)
+		g.referenced[name] = true
+
+		if _, ok := g.defs[name]; !ok {
+			g.structSchema(typ)
+		}
+
+		return &Schema{Ref: g.refPrefix + name}
+	}
+
+	// interfaces, functions, channels... accept anything
+	return &Schema{}
+}
+
+// fieldSchema returns the schema of a field of type typ with the validation
+// chain ct applied to it and whether the field is required.
+func (g *schemaGenerator) fieldSchema(typ reflect.Type, ct *cTag) (s *Schema, required bool) {
+
+	nullable := typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Interface ||
+		typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map
+	typ = derefType(typ)
+	s = g.typeSchema(typ)
+
+	var unsupported []string
+	var omitEmpty, constrained bool
+
+OUTER:
+	for ; ct != nil && ct.hasTag; ct = ct.next {
+
+		// warnings don't reject the values failing them
+		if ct.warn {
+			continue
+		}
+
+		switch ct.typeof {
+
+		case typeOmitEmpty:
+			omitEmpty = true
+			continue
+
+		case typeStructOnly, typeNoStructLevel:
+			continue
+
+		case typeEndKeys:
+			break OUTER
+
+		case typeDive:
+			g.applyDive(s, typ, ct.next)
+			constrained = true
+			break OUTER
+
+		case typeOr:
+
+			var alternatives []*Schema
+			var tags []string
+			ok := true
+
+			for {
+				alt := &Schema{}
+				if !g.applyTag(alt, typ, ct) {
+					ok = false
+				}
+				alternatives = append(alternatives, alt)
+				tags = append(tags, schemaTagString(ct))
+
+				if ct.isBlockEnd || ct.next == nil {
+					break
+				}
+				ct = ct.next
+			}
+
+			if ok {
+				s.AnyOf = append(s.AnyOf, alternatives...)
+				constrained = true
+			} else {
+				unsupported = append(unsupported, strings.Join(tags, schemaUnsupportedJoin))
+			}
+
+		default:
+
+			if ct.tag == requiredTag {
+				required = true
+				nullable = false
+			}
+
+			if g.applyTag(s, typ, ct) {
+				constrained = true
+			} else {
+				unsupported = append(unsupported, schemaTagString(ct))
+			}
+		}
+	}
+
+	// the validations following 'omitempty' only apply to values other than the zero value
+	if omitEmpty && constrained {
+		if zero := zeroSchema(typ, nullable); zero != nil {
+			s = &Schema{AnyOf: []*Schema{zero, s}, Unsupported: unsupported}
+			return
+		}
+	}
+
+	if nullable && s.Ref == "" && len(s.Type) == 1 {
+		s.Type = append(s.Type, schemaTypeNull)
+	}
+
+	s.Unsupported = unsupported
+	return
+}
+
+// zeroSchema returns the schema of the zero value of a field of type typ skipped by
+// 'omitempty', null for nullable fields, or nil when it cannot be expressed.
+func zeroSchema(typ reflect.Type, nullable bool) *Schema {
+
+	if nullable {
+		return &Schema{Type: SchemaType{schemaTypeNull}}
+	}
+
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return &Schema{Const: reflect.Zero(typ).Interface()}
+	}
+
+	return nil
+}
+
+// applyDive applies the validations following a 'dive' tag to the items of
+// the array or additional properties of the object s.
+func (g *schemaGenerator) applyDive(s *Schema, typ reflect.Type, ct *cTag) {
+
+	switch typ.Kind() {
+
+	case reflect.Slice, reflect.Array:
+		s.Items, _ = g.fieldSchema(typ.Elem(), ct)
+
+	case reflect.Map:
+
+		if ct != nil && ct.typeof == typeKeys {
+			s.PropertyNames, _ = g.fieldSchema(typ.Key(), ct.keys)
+			ct = ct.next
+		}
+
+		s.AdditionalProperties, _ = g.fieldSchema(typ.Elem(), ct)
+	}
+}
+
+// applyTag adds the keywords equivalent to the validation ct to s and
+// reports if the validation could be expressed.
+func (g *schemaGenerator) applyTag(s *Schema, typ reflect.Type, ct *cTag) bool {
+
+	kind := typ.Kind()
+	isBytes := (kind == reflect.Slice || kind == reflect.Array) && typ.Elem().Kind() == reflect.Uint8
+
+	if format, ok := schemaFormats[ct.tag]; ok {
+		if kind != reflect.String {
+			return false
+		}
+		if s.Format != "" && s.Format != format {
+			s.AllOf = append(s.AllOf, &Schema{Format: format})
+			return true
+		}
+		s.Format = format
+		return true
+	}
+
+	if pattern, ok := schemaPatterns[ct.tag]; ok {
+		if kind != reflect.String {
+			return false
+		}
+		addSchemaPattern(s, pattern)
+		return true
+	}
+
+	switch ct.tag {
+
+	case requiredTag:
+
+		switch kind {
+		case reflect.String:
+			setSchemaInt(&s.MinLength, 1, true)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64, reflect.Bool:
+			addSchemaNot(s, &Schema{Const: reflect.Zero(typ).Interface()})
+		}
+		return true
+
+	case "len", "min", "max", "gt", "gte", "lt", "lte":
+		if isBytes || !ct.hasParam {
+			return false
+		}
+		return applySchemaRange(s, typ, ct.tag, ct.param)
+
+	case "eq", "ne":
+
+		c, ok := schemaValue(typ, ct.param)
+		if !ok {
+			if kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map {
+				if isBytes {
+					return false
+				}
+				if ct.tag == "eq" {
+					return applySchemaRange(s, typ, "len", ct.param)
+				}
+			}
+			return false
+		}
+
+		if ct.tag == "eq" {
+			s.Const = c
+		} else {
+			addSchemaNot(s, &Schema{Const: c})
+		}
+		return true
+
+	case "oneof":
+
+		for _, p := range parseOneOfParam2(ct.param) {
+			c, ok := schemaValue(typ, p)
+			if !ok {
+				return false
+			}
+			s.Enum = append(s.Enum, c)
+		}
+		return true
+
+	case "unique":
+
+		if (kind != reflect.Slice && kind != reflect.Array) || isBytes || ct.hasParam {
+			return false
+		}
+		s.UniqueItems = true
+		return true
+
+	case "contains", "startswith", "endswith":
+
+		if kind != reflect.String {
+			return false
+		}
+
+		p := regexp.QuoteMeta(ct.param)
+
+		switch ct.tag {
+		case "startswith":
+			p = "^" + p
+		case "endswith":
+			p += "$"
+		}
+
+		addSchemaPattern(s, p)
+		return true
+	}
+
+	return false
+}
+
+// applySchemaRange applies the length or range validation tag with param
+// to s for a field of type typ.
+func applySchemaRange(s *Schema, typ reflect.Type, tag string, param string) bool {
+
+	var minKw, maxKw **int64
+
+	switch typ.Kind() {
+
+	case reflect.String:
+		minKw, maxKw = &s.MinLength, &s.MaxLength
+
+	case reflect.Slice, reflect.Array:
+		minKw, maxKw = &s.MinItems, &s.MaxItems
+
+	case reflect.Map:
+		minKw, maxKw = &s.MinProperties, &s.MaxProperties
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+
+		c, ok := schemaValue(typ, param)
+		if !ok {
+			return false
+		}
+
+		n := json.Number(fmt.Sprint(c))
+
+		switch tag {
+		case "len":
+			s.Const = c
+		case "min", "gte":
+			s.Minimum = n
+		case "max", "lte":
+			s.Maximum = n
+		case "gt":
+			s.ExclusiveMinimum = n
+		case "lt":
+			s.ExclusiveMaximum = n
+		}
+		return true
+
+	default:
+		return false
+	}
+
+	i, err := strconv.ParseInt(param, 0, 64)
+	if err != nil {
+		return false
+	}
+
+	switch tag {
+	case "len":
+		setSchemaInt(minKw, i, true)
+		setSchemaInt(maxKw, i, false)
+	case "min", "gte":
+		setSchemaInt(minKw, i, true)
+	case "gt":
+		setSchemaInt(minKw, i+1, true)
+	case "max", "lte":
+		setSchemaInt(maxKw, i, false)
+	case "lt":
+		setSchemaInt(maxKw, i-1, false)
+	}
+	return true
+}
+
+// setSchemaInt sets the integer keyword kw to i, keeping the most restrictive
+// value when already set.
+func setSchemaInt(kw **int64, i int64, isMin bool) {
+	if *kw != nil && ((isMin && **kw >= i) || (!isMin && **kw <= i)) {
+		return
+	}
+	*kw = &i
+}
+
+// addSchemaPattern sets the pattern of s, or adds it to 'allOf' when s
+// already has one since JSON Schema only allows a single pattern.
+func addSchemaPattern(s *Schema, pattern string) {
+	if s.Pattern == "" {
+		s.Pattern = pattern
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Pattern: pattern})
+}
+
+// addSchemaNot sets the 'not' keyword of s, or adds it to 'allOf' when s already
+// has one since a schema only has a single 'not'.
+func addSchemaNot(s *Schema, not *Schema) {
+	if s.Not == nil {
+		s.Not = not
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Not: not})
+}
+
+// schemaValue converts the tag param into a value of the JSON type
+// corresponding to typ.
+func schemaValue(typ reflect.Type, param string) (interface{}, bool) {
+
+	switch typ.Kind() {
+
+	case reflect.String:
+		return param, true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if typ == timeDurationType {
+			if d, err := time.ParseDuration(param); err == nil {
+				return int64(d), true
+			}
+		}
+		i, err := strconv.ParseInt(param, 0, 64)
+		return i, err == nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(param, 0, 64)
+		return u, err == nil
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(param, 64)
+		return f, err == nil
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(param)
+		return b, err == nil
+	}
+
+	return nil, false
+}
+
+// schemaTagString returns the tag as written in the struct tag.
+func schemaTagString(ct *cTag) string {
+	if ct.hasParam {
+		return ct.tag + tagKeySeparator + ct.param
+	}
+	return ct.tag
+}
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+var (
+	// schemaFormats maps validation tags to the JSON Schema 'format' they
+	// correspond to.
+	schemaFormats = map[string]string{
+		"email":            "email",
+		"url":              "uri",
+		"uri":              "uri-reference",
+		"uuid":             "uuid",
+		"uuid_rfc4122":     "uuid",
+		"hostname":         "hostname",
+		"hostname_rfc1123": "hostname",
+		"fqdn":             "hostname",
+		"ipv4":             "ipv4",
+		"ipv6":             "ipv6",
+	}
+
+	// schemaPatterns maps validation tags to the regular expression used to
+	// validate them, these are compatible with the ECMA 262 dialect used by
+	// JSON Schema.
+	schemaPatterns = map[string]string{
+		"alpha":         alphaRegexString,
+		"alphanum":      alphaNumericRegexString,
+		"numeric":       numericRegexString,
+		"number":        numberRegexString,
+		"hexadecimal":   hexadecimalRegexString,
+		"hexcolor":      hexColorRegexString,
+		"e164":          e164RegexString,
+		"base64":        base64RegexString,
+		"base64url":     base64URLRegexString,
+		"isbn10":        iSBN10RegexString,
+		"isbn13":        iSBN13RegexString,
+		"uuid3":         uUID3RegexString,
+		"uuid4":         uUID4RegexString,
+		"uuid5":         uUID5RegexString,
+		"uuid3_rfc4122": uUID3RFC4122RegexString,
+		"uuid4_rfc4122": uUID4RFC4122RegexString,
+		"uuid5_rfc4122": uUID5RFC4122RegexString,
+		"latitude":      latitudeRegexString,
+		"longitude":     longitudeRegexString,
+		"eth_addr":      ethAddressRegexString,
+		"btc_addr":      btcAddressRegexString,
+		"jwt":           jWTRegexString,
+		"bic":           bicRegexString,
+	}
+)
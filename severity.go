@@ -0,0 +1,211 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	warnTag        = "warn"
+	invalidWarnTag = "Invalid tag '%s' within '" + warnTag + "' on field '%s'"
+)
+
+// Severity is the severity of a FieldError.
+type Severity uint8
+
+// Severities
+const (
+	// SeverityError fails the validation.
+	SeverityError Severity = iota
+
+	// SeverityWarning is reported by validations returning a Result but
+	// does not fail them, set using the 'warn' tag.
+	SeverityWarning
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Result is the result of a validation, separating the errors failing it from the
+// warnings that do not.
+type Result struct {
+	Errors   ValidationErrors
+	Warnings ValidationErrors
+	err      error
+}
+
+// Err returns InvalidValidationError for bad values passed in, the ValidationErrors
+// failing the validation if any and nil otherwise, the same as the validation not
+// returning a Result.
+func (r *Result) Err() error {
+
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(r.Errors) > 0 {
+		return r.Errors
+	}
+
+	return nil
+}
+
+// Valid reports whether the validation passed, it may still have warnings.
+func (r *Result) Valid() bool {
+	return r.Err() == nil
+}
+
+// newResult returns the Result of a validation returning err and the warnings.
+func newResult(err error, warnings ValidationErrors) *Result {
+
+	res := &Result{Warnings: warnings}
+
+	if errs, ok := err.(ValidationErrors); ok {
+		res.Errors = errs
+	} else {
+		res.err = err
+	}
+
+	return res
+}
+
+// StructResult validates a structs exposed fields, the same as Struct, returning
+// the warnings of tags within 'warn' along with the errors.
+func (v *Validate) StructResult(s interface{}) *Result {
+	return v.StructResultCtx(context.Background(), s)
+}
+
+// StructResultCtx validates a structs exposed fields, the same as StructCtx, returning
+// the warnings of tags within 'warn' along with the errors.
+func (v *Validate) StructResultCtx(ctx context.Context, s interface{}) *Result {
+
+	var warnings ValidationErrors
+
+	err := v.StructCtx(withWarnings(ctx, &warnings), s)
+
+	return newResult(err, warnings)
+}
+
+// VarResult validates a single variable, the same as Var, returning the warnings of
+// tags within 'warn' along with the errors.
+func (v *Validate) VarResult(field interface{}, tag string) *Result {
+	return v.VarResultCtx(context.Background(), field, tag)
+}
+
+// VarResultCtx validates a single variable, the same as VarCtx, returning the warnings
+// of tags within 'warn' along with the errors.
+func (v *Validate) VarResultCtx(ctx context.Context, field interface{}, tag string) *Result {
+
+	var warnings ValidationErrors
+
+	err := v.VarCtx(withWarnings(ctx, &warnings), field, tag)
+
+	return newResult(err, warnings)
+}
+
+// withWarnings returns a copy of ctx collecting the warnings of the validation it is
+// passed to in warnings.
+func withWarnings(ctx context.Context, warnings *ValidationErrors) context.Context {
+	return context.WithValue(ctx, warningsCtxKey, warnings)
+}
+
+// flushWarnings hands the warnings collected to the Result of the validation, if any,
+// and resets them.
+func (v *validate) flushWarnings(ctx context.Context) {
+
+	if len(v.warns) == 0 {
+		return
+	}
+
+	if ctx != nil {
+		if warnings, ok := ctx.Value(warningsCtxKey).(*ValidationErrors); ok {
+			*warnings = append(*warnings, v.warns...)
+		}
+	}
+
+	v.warns = nil
+}
+
+// splitTags splits tag on the commas that are not within the parentheses of a warn
+// tag eg. 'warn(min=12,max=64)' or of a tag group eg. '(len=3,numeric)', the tags
+// starting with 'warn(', '(' or '!' and the alternatives of an or starting with
+// '(' or '!'. Other parentheses are those of params eg. 'contains=(' and unbalanced
+// ones are not taken into account.
+func splitTags(tag string) []string {
+
+	if !strings.Contains(tag, "(") {
+		return strings.Split(tag, tagSeparator)
+	}
+
+	var tags []string
+	depth, start := 0, 0
+	grouping := opensGroup(tag)
+
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '(':
+			if grouping {
+				depth++
+			}
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case '|':
+			if depth == 0 && i+1 < len(tag) && (tag[i+1] == groupStart || tag[i+1] == notOperator) {
+				grouping = true
+			}
+		case ',':
+			if depth == 0 {
+				tags = append(tags, tag[start:i])
+				start = i + 1
+				grouping = opensGroup(tag[start:])
+			}
+		}
+	}
+
+	if depth != 0 {
+		return strings.Split(tag, tagSeparator)
+	}
+
+	return append(tags, tag[start:])
+}
+
+// opensGroup reports whether the tag starting t is a warn tag or a tag group, whose
+// parentheses group tags.
+func opensGroup(t string) bool {
+	return strings.HasPrefix(t, warnTag+"(") || (len(t) > 0 && (t[0] == groupStart || t[0] == notOperator))
+}
+
+// extractWarnTags returns the tags within a 'warn(...)' tag.
+func extractWarnTags(t string) (string, bool) {
+
+	if !strings.HasPrefix(t, warnTag+"(") || !strings.HasSuffix(t, ")") {
+		return "", false
+	}
+
+	return t[len(warnTag)+1 : len(t)-1], true
+}
+
+// parseWarnTags parses the tags within a 'warn(...)' tag, whose failures are warnings.
+func (v *Validate) parseWarnTags(tags string, fieldName string) (first *cTag, last *cTag) {
+
+	first, last = v.parseFieldTagsRecursive(tags, fieldName, "", false)
+
+	for ct := first; ct != nil; ct = ct.next {
+
+		if ct.typeof != typeDefault && ct.typeof != typeOr && ct.typeof != typeIsDefault && ct.typeof != typeGroup {
+			panic(strings.TrimSpace(fmt.Sprintf(invalidWarnTag, ct.aliasTag, fieldName)))
+		}
+
+		ct.warn = true
+	}
+
+	return
+}
@@ -166,10 +166,16 @@ func (v *validate) ReportValidationErrors(relativeNamespace, relativeStructNames
 
 	for i := 0; i < len(errs); i++ {
 
-		err = errs[i].(*fieldError)
+		switch e := errs[i].(type) {
+		case *jsonFieldError:
+			err = e.fieldError
+		default:
+			err = e.(*fieldError)
+		}
+
 		err.ns = string(append(append(v.ns, relativeNamespace...), err.ns...))
 		err.structNs = string(append(append(v.actualNs, relativeStructNamespace...), err.structNs...))
 
-		v.errs = append(v.errs, err)
+		v.errs = append(v.errs, errs[i])
 	}
 }
@@ -28,6 +28,16 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 			translation: "{0} is a required field",
 			override:    false,
 		},
+		{
+			tag:         "excluded_if",
+			translation: "{0} is an excluded field",
+			override:    false,
+		},
+		{
+			tag:         "excluded_unless",
+			translation: "{0} is an excluded field",
+			override:    false,
+		},
 		{
 			tag: "len",
 			customRegisFunc: func(ut ut.Translator) (err error) {
@@ -148,6 +148,8 @@ func TestTranslations(t *testing.T) {
 		PostCode          string            `validate:"postcode_iso3166_alpha2=SG"`
 		PostCodeCountry   string
 		PostCodeByField   string `validate:"postcode_iso3166_alpha2_field=PostCodeCountry"`
+		ExcludedIf        string `validate:"excluded_if=Inner.EqCSFieldString 1234"`
+		ExcludedUnless    string `validate:"excluded_unless=Inner.EqCSFieldString 5678"`
 	}
 
 	var test Test
@@ -201,6 +203,9 @@ func TestTranslations(t *testing.T) {
 	test.UniqueMap = map[string]string{"key1": "1234", "key2": "1234"}
 	test.Datetime = "2008-Feb-01"
 
+	test.ExcludedIf = "excluded"
+	test.ExcludedUnless = "excluded"
+
 	err = validate.Struct(test)
 	NotEqual(t, err, nil)
 
@@ -211,6 +216,14 @@ func TestTranslations(t *testing.T) {
 		ns       string
 		expected string
 	}{
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "ExcludedIf is an excluded field",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "ExcludedUnless is an excluded field",
+		},
 		{
 			ns:       "Test.IsColor",
 			expected: "IsColor must be a valid color",
@@ -28,6 +28,16 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 			translation: "فیلد {0} اجباری میباشد",
 			override:    false,
 		},
+		{
+			tag:         "excluded_if",
+			translation: "فیلد {0} باید خالی باشد",
+			override:    false,
+		},
+		{
+			tag:         "excluded_unless",
+			translation: "فیلد {0} باید خالی باشد",
+			override:    false,
+		},
 		{
 			tag: "len",
 			customRegisFunc: func(ut ut.Translator) (err error) {
@@ -147,6 +147,8 @@ func TestTranslations(t *testing.T) {
 		PostCode          string            `validate:"postcode_iso3166_alpha2=SG"`
 		PostCodeCountry   string
 		PostCodeByField   string `validate:"postcode_iso3166_alpha2_field=PostCodeCountry"`
+		ExcludedIf        string `validate:"excluded_if=Inner.EqCSFieldString 1234"`
+		ExcludedUnless    string `validate:"excluded_unless=Inner.EqCSFieldString 5678"`
 	}
 
 	var test Test
@@ -200,6 +202,9 @@ func TestTranslations(t *testing.T) {
 	test.UniqueMap = map[string]string{"key1": "1234", "key2": "1234"}
 	test.Datetime = "2008-Feb-01"
 
+	test.ExcludedIf = "excluded"
+	test.ExcludedUnless = "excluded"
+
 	err = validate.Struct(test)
 	NotEqual(t, err, nil)
 
@@ -210,6 +215,14 @@ func TestTranslations(t *testing.T) {
 		ns       string
 		expected string
 	}{
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "فیلد ExcludedIf باید خالی باشد",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "فیلد ExcludedUnless باید خالی باشد",
+		},
 		{
 			ns:       "Test.IsColor",
 			expected: "IsColor باید یک رنگ معتبر باشد",
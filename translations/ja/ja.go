@@ -29,6 +29,16 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 			translation: "{0}は必須フィールドです",
 			override:    false,
 		},
+		{
+			tag:         "excluded_if",
+			translation: "{0}は除外されたフィールドです",
+			override:    false,
+		},
+		{
+			tag:         "excluded_unless",
+			translation: "{0}は除外されたフィールドです",
+			override:    false,
+		},
 		{
 			tag: "len",
 			customRegisFunc: func(ut ut.Translator) (err error) {
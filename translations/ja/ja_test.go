@@ -138,6 +138,8 @@ func TestTranslations(t *testing.T) {
 		StrPtrGte         *string   `validate:"gte=10"`
 		OneOfString       string    `validate:"oneof=red green"`
 		OneOfInt          int       `validate:"oneof=5 63"`
+		ExcludedIf        string    `validate:"excluded_if=Inner.EqCSFieldString 1234"`
+		ExcludedUnless    string    `validate:"excluded_unless=Inner.EqCSFieldString 5678"`
 	}
 
 	var test Test
@@ -184,6 +186,9 @@ func TestTranslations(t *testing.T) {
 	test.StrPtrMaxLen = &s
 	test.StrPtrLen = &s
 
+	test.ExcludedIf = "excluded"
+	test.ExcludedUnless = "excluded"
+
 	err = validate.Struct(test)
 	NotEqual(t, err, nil)
 
@@ -194,6 +199,14 @@ func TestTranslations(t *testing.T) {
 		ns       string
 		expected string
 	}{
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "ExcludedIfは除外されたフィールドです",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "ExcludedUnlessは除外されたフィールドです",
+		},
 		{
 			ns:       "Test.IsColor",
 			expected: "IsColorは正しい色でなければなりません",
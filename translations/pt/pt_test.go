@@ -146,6 +146,8 @@ func TestTranslations(t *testing.T) {
 		LowercaseString   string            `validate:"lowercase"`
 		UppercaseString   string            `validate:"uppercase"`
 		Datetime          string            `validate:"datetime=2006-01-02"`
+		ExcludedIf        string            `validate:"excluded_if=Inner.EqCSFieldString 1234"`
+		ExcludedUnless    string            `validate:"excluded_unless=Inner.EqCSFieldString 5678"`
 	}
 
 	var test Test
@@ -199,6 +201,9 @@ func TestTranslations(t *testing.T) {
 	test.UniqueMap = map[string]string{"key1": "1234", "key2": "1234"}
 	test.Datetime = "2008-Feb-01"
 
+	test.ExcludedIf = "excluded"
+	test.ExcludedUnless = "excluded"
+
 	err = validate.Struct(test)
 	NotEqual(t, err, nil)
 
@@ -209,6 +214,14 @@ func TestTranslations(t *testing.T) {
 		ns       string
 		expected string
 	}{
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "ExcludedIf deve estar vazio",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "ExcludedUnless deve estar vazio",
+		},
 		{
 			ns:       "Test.IsColor",
 			expected: "IsColor deve ser uma cor válida",
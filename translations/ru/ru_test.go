@@ -163,6 +163,8 @@ func TestTranslations(t *testing.T) {
 		UniqueSlice             []string          `validate:"unique"`
 		UniqueArray             [3]string         `validate:"unique"`
 		UniqueMap               map[string]string `validate:"unique"`
+		ExcludedIf              string            `validate:"excluded_if=Inner.EqCSFieldString 1234"`
+		ExcludedUnless          string            `validate:"excluded_unless=Inner.EqCSFieldString 5678"`
 	}
 
 	var test Test
@@ -218,6 +220,9 @@ func TestTranslations(t *testing.T) {
 	test.UniqueSlice = []string{"1234", "1234"}
 	test.UniqueMap = map[string]string{"key1": "1234", "key2": "1234"}
 
+	test.ExcludedIf = "excluded"
+	test.ExcludedUnless = "excluded"
+
 	err = validate.Struct(test)
 	NotEqual(t, err, nil)
 
@@ -228,6 +233,14 @@ func TestTranslations(t *testing.T) {
 		ns       string
 		expected string
 	}{
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "ExcludedIf исключенное поле",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "ExcludedUnless исключенное поле",
+		},
 		{
 			ns:       "Test.IsColor",
 			expected: "IsColor должен быть цветом",
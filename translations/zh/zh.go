@@ -29,6 +29,16 @@ func RegisterDefaultTranslations(v *validator.Validate, trans ut.Translator) (er
 			translation: "{0}为必填字段",
 			override:    false,
 		},
+		{
+			tag:         "excluded_if",
+			translation: "{0}为禁填字段",
+			override:    false,
+		},
+		{
+			tag:         "excluded_unless",
+			translation: "{0}为禁填字段",
+			override:    false,
+		},
 		{
 			tag: "len",
 			customRegisFunc: func(ut ut.Translator) (err error) {
@@ -147,6 +147,8 @@ func TestTranslations(t *testing.T) {
 		LowercaseString       string    `validate:"lowercase"`
 		UppercaseString       string    `validate:"uppercase"`
 		Datetime              string    `validate:"datetime=2006-01-02"`
+		ExcludedIf            string    `validate:"excluded_if=Inner.EqCSFieldString 1234"`
+		ExcludedUnless        string    `validate:"excluded_unless=Inner.EqCSFieldString 5678"`
 	}
 
 	var test Test
@@ -205,6 +207,9 @@ func TestTranslations(t *testing.T) {
 
 	test.Datetime = "20060102"
 
+	test.ExcludedIf = "excluded"
+	test.ExcludedUnless = "excluded"
+
 	err = validate.Struct(test)
 	NotEqual(t, err, nil)
 
@@ -215,6 +220,14 @@ func TestTranslations(t *testing.T) {
 		ns       string
 		expected string
 	}{
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "ExcludedIf为禁填字段",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "ExcludedUnless为禁填字段",
+		},
 		{
 			ns:       "Test.IsColor",
 			expected: "IsColor必须是一个有效的颜色",
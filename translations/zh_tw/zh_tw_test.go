@@ -139,6 +139,8 @@ func TestTranslations(t *testing.T) {
 		OneOfString       string    `validate:"oneof=red green"`
 		OneOfInt          int       `validate:"oneof=5 63"`
 		Datetime          string    `validate:"datetime=2006-01-02"`
+		ExcludedIf        string    `validate:"excluded_if=Inner.EqCSFieldString 1234"`
+		ExcludedUnless    string    `validate:"excluded_unless=Inner.EqCSFieldString 5678"`
 	}
 
 	var test Test
@@ -187,6 +189,9 @@ func TestTranslations(t *testing.T) {
 
 	test.Datetime = "2008-Feb-01"
 
+	test.ExcludedIf = "excluded"
+	test.ExcludedUnless = "excluded"
+
 	err = validate.Struct(test)
 	NotEqual(t, err, nil)
 
@@ -197,6 +202,14 @@ func TestTranslations(t *testing.T) {
 		ns       string
 		expected string
 	}{
+		{
+			ns:       "Test.ExcludedIf",
+			expected: "ExcludedIf為禁填欄位",
+		},
+		{
+			ns:       "Test.ExcludedUnless",
+			expected: "ExcludedUnless為禁填欄位",
+		},
 		{
 			ns:       "Test.IsColor",
 			expected: "IsColor必須是一個有效的顏色",
@@ -8,9 +8,17 @@ import (
 )
 
 // extractTypeInternal gets the actual underlying type of field value.
-// It will dive into pointers, customTypes and return you the
+// It will dive into pointers, customTypes, sql.Null* and Optional wrappers
+// and return you the
 // underlying value and it's kind.
 func (v *validate) extractTypeInternal(current reflect.Value, nullable bool) (reflect.Value, reflect.Kind, bool) {
+	return v.extractFieldType(current, nullable, true)
+}
+
+// extractFieldType does the same as extractTypeInternal, only looking for wrappers
+// when mayWrap, eg. false for the fields whose type is known not to be one. Null
+// wrappers are returned as a nil interface, validated like nil pointers.
+func (v *validate) extractFieldType(current reflect.Value, nullable bool, mayWrap bool) (reflect.Value, reflect.Kind, bool) {
 
 BEGIN:
 	switch current.Kind() {
@@ -45,10 +53,26 @@ BEGIN:
 
 			if fn, ok := v.v.customFuncs[current.Type()]; ok {
 				current = reflect.ValueOf(fn(current))
+				mayWrap = true
 				goto BEGIN
 			}
 		}
 
+		if !mayWrap {
+			return current, current.Kind(), nullable
+		}
+
+		// sql.Null* and other wrappers, unless they wrap a value of their own type
+		if val, ok := v.v.unwrap(current); ok && (!val.IsValid() || val.Type() != current.Type()) {
+
+			if !val.IsValid() {
+				return reflect.Zero(interfaceType), reflect.Interface, true
+			}
+
+			current = val
+			goto BEGIN
+		}
+
 		return current, current.Kind(), nullable
 	}
 }
@@ -220,6 +244,31 @@ BEGIN:
 	panic("Invalid field namespace")
 }
 
+// getConditionalFieldOK retrieves the field referenced by a conditional tag, the namespace is relative
+// to parent unless prefixed with one '^' per level to walk up to the enclosing structs,
+// eg. '^Kind' is the field Kind of the struct containing parent.
+func (v *validate) getConditionalFieldOK(parent reflect.Value, namespace string) (current reflect.Value, kind reflect.Kind, nullable bool, found bool) {
+
+	level := 0
+	for strings.HasPrefix(namespace, parentNamespacePrefix) {
+		namespace = namespace[len(parentNamespacePrefix):]
+		level++
+	}
+
+	if level > 0 {
+
+		// the last struct is parent itself
+		idx := len(v.parents) - 1 - level
+		if idx < 0 {
+			return
+		}
+
+		parent = v.parents[idx]
+	}
+
+	return v.getStructFieldOKInternal(parent, namespace)
+}
+
 // asInt returns the parameter as a int64
 // or panics if it can't convert
 func asInt(param string) int64 {
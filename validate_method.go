@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// validatable is implemented by types validating themselves, their Validate
+// method is called during traversal after all of their tag validations.
+//
+// The method of the value passed to Struct, Var and their variants is never called,
+// so that the method may validate its own value with them.
+type validatable interface {
+	Validate() error
+}
+
+// validatableCtx is the same as validatable but is passed the context.Context
+// of the validation.
+type validatableCtx interface {
+	Validate(ctx context.Context) error
+}
+
+var (
+	validatableType    = reflect.TypeOf((*validatable)(nil)).Elem()
+	validatableCtxType = reflect.TypeOf((*validatableCtx)(nil)).Elem()
+)
+
+// hasValidateMethod reports whether the values of the type, once dereferenced, may have
+// a Validate method, which is only known when validating interfaces, wrapped values or
+// those converted by a custom type func.
+func (v *Validate) hasValidateMethod(typ reflect.Type) bool {
+
+	if v.mayWrap(typ) {
+		return true
+	}
+
+	ptr := reflect.PtrTo(derefType(typ))
+
+	return ptr.Implements(validatableType) || ptr.Implements(validatableCtxType)
+}
+
+// extractNoValidateMethod removes the 'novalidatemethod' tag from the field's
+// tags, reporting whether it was present.
+func extractNoValidateMethod(tag string) (string, bool) {
+
+	if !strings.Contains(tag, noValidateMethodTag) {
+		return tag, false
+	}
+
+	tags := strings.Split(tag, tagSeparator)
+	kept := tags[:0]
+	found := false
+
+	for _, t := range tags {
+		if t == noValidateMethodTag {
+			found = true
+			continue
+		}
+		kept = append(kept, t)
+	}
+
+	return strings.Join(kept, tagSeparator), found
+}
+
+// callValidateMethod calls the Validate method of current when its type, or a
+// pointer to it when addressable, implements validatable or validatableCtx.
+func (v *validate) callValidateMethod(ctx context.Context, current reflect.Value) error {
+
+	if !current.IsValid() || !current.CanInterface() {
+		return nil
+	}
+
+	// predeclared and unnamed types, other than structs which may embed
+	// a type, have no methods
+	if typ := current.Type(); len(typ.PkgPath()) == 0 && typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var i interface{}
+
+	if current.CanAddr() {
+		i = current.Addr().Interface()
+	} else {
+		i = current.Interface()
+	}
+
+	switch t := i.(type) {
+	case validatable:
+		return t.Validate()
+	case validatableCtx:
+		return t.Validate(ctx)
+	}
+
+	return nil
+}
+
+// fieldValidateMethod calls the Validate method of a non struct field, see validateStruct
+// for structs.
+func (v *validate) fieldValidateMethod(ctx context.Context, current reflect.Value, ns []byte, structNs []byte, cf *cField) {
+
+	if cf.noValidateMethod || !cf.validateMethod || v.limitReached() {
+		return
+	}
+
+	if err := v.callValidateMethod(ctx, current); err != nil {
+		v.reportValidateMethodError(err, current, string(append(ns, cf.altName...)), string(append(structNs, cf.name...)), len(cf.altName), len(cf.name), len(v.errs))
+	}
+}
+
+// reportValidateMethodError reports the error returned by the Validate method of the value
+// found at the namespace ns, ValidationErrors are prepended with the namespace the same way
+// as ReportValidationErrors, without the name of their top level struct, and any other error
+// is reported as a FieldError of the value with the 'validatemethod' tag and the error's
+// message as param. ValidationErrors already reported since the errors at from, which are
+// those of the value, are dropped eg. when the method validates its value using Struct.
+func (v *validate) reportValidateMethodError(err error, current reflect.Value, ns string, structNs string, fieldLen int, structFieldLen int, from int) {
+
+	if !v.v.hasTagNameFunc {
+		structNs = ns
+	}
+
+	if errs, ok := err.(ValidationErrors); ok {
+
+		var fe *fieldError
+
+		for i := 0; i < len(errs); i++ {
+
+			// copy the errors, which belong to the method, before prefixing their namespace
+			switch e := errs[i].(type) {
+			case *fieldError:
+				cp := *e
+				fe = &cp
+			case *jsonFieldError:
+				cp := *e.fieldError
+				fe = &cp
+			default:
+				fe = copyFieldError(v.v, errs[i])
+			}
+
+			nsLen := len(fe.ns)
+			fe.ns = joinNamespace(ns, fe.ns[fe.rootLen:])
+			fe.structNs = joinNamespace(structNs, fe.structNs[fe.rootLen:])
+			fe.rootLen = 0
+			fe.indexes = shiftIndexes(fe.indexes, len(fe.ns)-nsLen)
+
+			if hasFieldError(v.errs[from:], fe.ns, fe.tag) {
+				continue
+			}
+
+			v.errs = append(v.errs, fe)
+		}
+		return
+	}
+
+	v.errs = append(v.errs,
+		&fieldError{
+			v:              v.v,
+			tag:            validateMethodTag,
+			actualTag:      validateMethodTag,
+			ns:             ns,
+			structNs:       structNs,
+			fieldLen:       uint8(fieldLen),
+			structfieldLen: uint8(structFieldLen),
+			value:          current.Interface(),
+			param:          err.Error(),
+			kind:           current.Kind(),
+			typ:            current.Type(),
+		},
+	)
+}
+
+// copyFieldError returns a fieldError holding the values of fe, a FieldError
+// implemented by another type.
+func copyFieldError(v *Validate, fe FieldError) *fieldError {
+	return &fieldError{
+		v:              v,
+		tag:            fe.Tag(),
+		actualTag:      fe.ActualTag(),
+		ns:             fe.Namespace(),
+		structNs:       fe.StructNamespace(),
+		fieldLen:       uint8(len(fe.Field())),
+		structfieldLen: uint8(len(fe.StructField())),
+		value:          fe.Value(),
+		param:          fe.Param(),
+		kind:           fe.Kind(),
+		typ:            fe.Type(),
+		severity:       fe.Severity(),
+		cause:          fe.Cause(),
+	}
+}
+
+// hasFieldError reports whether errs has an error of the tag at the namespace ns.
+func hasFieldError(errs ValidationErrors, ns string, tag string) bool {
+
+	for _, fe := range errs {
+		if fe.Namespace() == ns && fe.Tag() == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinNamespace appends the relative namespace to ns.
+func joinNamespace(ns string, relative string) string {
+
+	if len(ns) == 0 {
+		return relative
+	}
+
+	if len(relative) == 0 || strings.HasPrefix(relative, leftBracket) {
+		return ns + relative
+	}
+
+	return ns + namespaceSeparator + relative
+}
+
+// lastNamespaceSegment returns the length of the last segment of the namespace ns.
+func lastNamespaceSegment(ns string) int {
+	return len(ns) - strings.LastIndex(ns, namespaceSeparator) - 1
+}
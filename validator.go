@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // per validate construct
@@ -14,29 +16,88 @@ type validate struct {
 	ns             []byte
 	actualNs       []byte
 	errs           ValidationErrors
+	warns          ValidationErrors    // failures of tags within 'warn'
 	includeExclude map[string]struct{} // reset only if StructPartial or StructExcept are called, no need otherwise
+	groups         []string            // only set during StructGroups
 	ffn            FilterFunc
 	slflParent     reflect.Value // StructLevel & FieldLevel
 	slCurrent      reflect.Value // StructLevel & FieldLevel
 	flField        reflect.Value // StructLevel & FieldLevel
 	cf             *cField       // StructLevel & FieldLevel
 	ct             *cTag         // StructLevel & FieldLevel
+	paramCt        *cTag         // tag whose param referencing a field or the param source is resolved
+	param          string        // resolved param of paramCt
+	causeCt        *cTag         // tag whose validation last returned cause
+	cause          error         // error returned by the validation of causeCt, if any
 	misc           []byte        // misc reusable
 	str1           string        // misc reusable
 	str2           string        // misc reusable
 	fldIsPointer   bool          // StructLevel & FieldLevel
 	isPartial      bool
 	hasExcludes    bool
+	hasGroups      bool
+	maxErrs        int              // 0 collects all errors
+	clock          func() time.Time // current time of the time based tags
+	strictMaps     bool             // only set during Map
+	parents        []reflect.Value  // structs being validated, innermost last
+}
+
+// inGroups reports if any of the field's groups is one of the groups being validated,
+// fields not belonging to any group are always validated.
+func (v *validate) inGroups(cf *cField) bool {
+
+	if len(cf.groups) == 0 {
+		return true
+	}
+
+	for _, g := range cf.groups {
+		for _, active := range v.groups {
+			if g == active {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// setCause sets the error returned by the validation of the current tag, nil when it passes.
+func (v *validate) setCause(err error) {
+	v.causeCt, v.cause = v.ct, err
+}
+
+// causeOf returns the error returned by the validation of the tag that failed, if any.
+func (v *validate) causeOf(ct *cTag) error {
+	if v.causeCt == ct {
+		return v.cause
+	}
+	return nil
+}
+
+// limitReached reports if the maximum number of errors to collect has been
+// reached and validation should stop.
+func (v *validate) limitReached() bool {
+	return v.maxErrs > 0 && len(v.errs) >= v.maxErrs
+}
+
+// limitErrs drops any errors over the maximum, these can only have been
+// added by struct level validations reporting multiple errors at once.
+func (v *validate) limitErrs() {
+	if v.limitReached() {
+		v.errs = v.errs[:v.maxErrs]
+	}
 }
 
 // parent and current will be the same the first run of validateStruct
-func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, current reflect.Value, typ reflect.Type, ns []byte, structNs []byte, ct *cTag) {
+func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, current reflect.Value, typ reflect.Type, ns []byte, structNs []byte, ct *cTag, callMethod bool) {
 
 	cs, ok := v.v.structCache.Get(typ)
 	if !ok {
 		cs = v.v.extractStructCache(current, typ.Name())
 	}
 
+	var rootLen int
+
 	if len(ns) == 0 && len(cs.name) != 0 {
 
 		ns = append(ns, cs.name...)
@@ -44,8 +105,14 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 
 		structNs = append(structNs, cs.name...)
 		structNs = append(structNs, '.')
+
+		rootLen = len(ns)
 	}
 
+	errsFrom, warnsFrom := len(v.errs), len(v.warns)
+
+	v.parents = append(v.parents, current)
+
 	// ct is nil on top level struct, and structs as fields that have no tag info
 	// so if nil or if not nil and the structonly tag isn't present
 	if ct == nil || ct.typeof != typeStructOnly {
@@ -54,8 +121,16 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 
 		for i := 0; i < len(cs.fields); i++ {
 
+			if v.limitReached() {
+				break
+			}
+
 			f = cs.fields[i]
 
+			if v.hasGroups && !v.inGroups(f) {
+				continue
+			}
+
 			if v.isPartial {
 
 				if v.ffn != nil {
@@ -81,7 +156,7 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 	// check if any struct level validations, after all field validations already checked.
 	// first iteration will have no info about nostructlevel tag, and is checked prior to
 	// calling the next iteration of validateStruct called from traverseField.
-	if cs.fn != nil {
+	if cs.fn != nil && !v.limitReached() {
 
 		v.slflParent = parent
 		v.slCurrent = current
@@ -90,6 +165,25 @@ func (v *validate) validateStruct(ctx context.Context, parent reflect.Value, cur
 
 		cs.fn(ctx, v)
 	}
+
+	// check if the struct validates itself, after its fields and struct level validations.
+	if callMethod && !v.limitReached() {
+
+		if err := v.callValidateMethod(ctx, current); err != nil {
+
+			v.str1 = strings.TrimSuffix(string(ns), namespaceSeparator)
+			v.str2 = strings.TrimSuffix(string(structNs), namespaceSeparator)
+
+			v.reportValidateMethodError(err, current, v.str1, v.str2, lastNamespaceSegment(v.str1), lastNamespaceSegment(v.str2), errsFrom)
+		}
+	}
+
+	if rootLen > 0 {
+		setRootLen(v.errs[errsFrom:], rootLen)
+		setRootLen(v.warns[warnsFrom:], rootLen)
+	}
+
+	v.parents = v.parents[:len(v.parents)-1]
 }
 
 // traverseField validates any field, be it a struct or single field, ensures it's validity and passes it along to be validated via it's tag options
@@ -97,7 +191,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 	var typ reflect.Type
 	var kind reflect.Kind
 
-	current, kind, v.fldIsPointer = v.extractTypeInternal(current, false)
+	current, kind, v.fldIsPointer = v.extractFieldType(current, false, cf.mayWrap)
 
 	switch kind {
 	case reflect.Ptr, reflect.Interface, reflect.Invalid:
@@ -106,6 +200,39 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 			return
 		}
 
+		// warnings don't stop the validation of the rest of the tags
+		for ct.warn && ct.typeof != typeIsDefault && (kind == reflect.Invalid || !ct.runValidationWhenNil) {
+
+			fe := &fieldError{
+				v:              v.v,
+				tag:            ct.aliasTag,
+				actualTag:      ct.tag,
+				ns:             string(append(ns, cf.altName...)),
+				structNs:       string(append(structNs, cf.name...)),
+				fieldLen:       uint8(len(cf.altName)),
+				structfieldLen: uint8(len(cf.name)),
+				messages:       cf.messages,
+				param:          ct.param,
+				kind:           kind,
+				severity:       SeverityWarning,
+			}
+
+			if !v.v.hasTagNameFunc {
+				fe.structNs = fe.ns
+			}
+
+			if kind != reflect.Invalid {
+				fe.value = current.Interface()
+				fe.typ = current.Type()
+			}
+
+			v.warns = append(v.warns, fe)
+
+			if ct = ct.next; ct == nil {
+				return
+			}
+		}
+
 		if ct.typeof == typeOmitEmpty || ct.typeof == typeIsDefault {
 			return
 		}
@@ -127,6 +254,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 						structNs:       v.str2,
 						fieldLen:       uint8(len(cf.altName)),
 						structfieldLen: uint8(len(cf.name)),
+						messages:       cf.messages,
 						param:          ct.param,
 						kind:           kind,
 					},
@@ -150,6 +278,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 						structNs:       v.str2,
 						fieldLen:       uint8(len(cf.altName)),
 						structfieldLen: uint8(len(cf.name)),
+						messages:       cf.messages,
 						value:          current.Interface(),
 						param:          ct.param,
 						kind:           kind,
@@ -164,7 +293,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 
 		typ = current.Type()
 
-		if typ != timeType {
+		if typ != timeType && !isBigNumberType(typ) {
 
 			if ct != nil {
 
@@ -195,6 +324,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 								structNs:       v.str2,
 								fieldLen:       uint8(len(cf.altName)),
 								structfieldLen: uint8(len(cf.name)),
+								messages:       cf.messages,
 								value:          current.Interface(),
 								param:          ct.param,
 								kind:           kind,
@@ -222,12 +352,13 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 				structNs = append(append(structNs, cf.name...), '.')
 			}
 
-			v.validateStruct(ctx, parent, current, typ, ns, structNs, ct)
+			v.validateStruct(ctx, parent, current, typ, ns, structNs, ct, !cf.noValidateMethod && cf.validateMethod)
 			return
 		}
 	}
 
 	if ct == nil || !ct.hasTag {
+		v.fieldValidateMethod(ctx, current, ns, structNs, cf)
 		return
 	}
 
@@ -236,6 +367,7 @@ func (v *validate) traverseField(ctx context.Context, parent reflect.Value, curr
 OUTER:
 	for {
 		if ct == nil {
+			v.fieldValidateMethod(ctx, current, ns, structNs, cf)
 			return
 		}
 
@@ -261,6 +393,8 @@ OUTER:
 
 		case typeDive:
 
+			v.fieldValidateMethod(ctx, current, ns, structNs, cf)
+
 			ct = ct.next
 
 			// traverse slice or map here
@@ -269,10 +403,15 @@ OUTER:
 			case reflect.Slice, reflect.Array:
 
 				var i64 int64
-				reusableCF := &cField{}
+				reusableCF := &cField{noValidateMethod: cf.noValidateMethod, messages: cf.messages, methods: cf.methods,
+					mayWrap: v.v.mayWrap(typ.Elem()), validateMethod: v.v.hasValidateMethod(typ.Elem())}
 
 				for i := 0; i < current.Len(); i++ {
 
+					if v.limitReached() {
+						return
+					}
+
 					i64 = int64(i)
 
 					v.misc = append(v.misc[0:0], cf.name...)
@@ -293,16 +432,28 @@ OUTER:
 
 						reusableCF.altName = string(v.misc)
 					}
+
+					errsFrom, warnsFrom := len(v.errs), len(v.warns)
+
 					v.traverseField(ctx, parent, current.Index(i), ns, structNs, reusableCF, ct)
+
+					setIndex(v.errs[errsFrom:], len(ns)+len(cf.altName))
+					setIndex(v.warns[warnsFrom:], len(ns)+len(cf.altName))
 				}
 
 			case reflect.Map:
 
 				var pv string
-				reusableCF := &cField{}
+				reusableCF := &cField{noValidateMethod: cf.noValidateMethod, messages: cf.messages, methods: cf.methods,
+					mayWrap:        v.v.mayWrap(typ.Elem()) || v.v.mayWrap(typ.Key()),
+					validateMethod: v.v.hasValidateMethod(typ.Elem()) || v.v.hasValidateMethod(typ.Key())}
 
 				for _, key := range current.MapKeys() {
 
+					if v.limitReached() {
+						return
+					}
+
 					pv = fmt.Sprintf("%v", key.Interface())
 
 					v.misc = append(v.misc[0:0], cf.name...)
@@ -353,6 +504,7 @@ OUTER:
 				v.flField = current
 				v.cf = cf
 				v.ct = ct
+				v.setCause(nil)
 
 				if ct.fn(ctx, v) {
 
@@ -362,6 +514,7 @@ OUTER:
 						ct = ct.next
 
 						if ct == nil {
+							v.fieldValidateMethod(ctx, current, ns, structNs, cf)
 							return
 						}
 
@@ -389,51 +542,109 @@ OUTER:
 						v.str2 = v.str1
 					}
 
+					var fe *fieldError
+
 					if ct.hasAlias {
 
-						v.errs = append(v.errs,
-							&fieldError{
-								v:              v.v,
-								tag:            ct.aliasTag,
-								actualTag:      ct.actualAliasTag,
-								ns:             v.str1,
-								structNs:       v.str2,
-								fieldLen:       uint8(len(cf.altName)),
-								structfieldLen: uint8(len(cf.name)),
-								value:          current.Interface(),
-								param:          ct.param,
-								kind:           kind,
-								typ:            typ,
-							},
-						)
+						fe = &fieldError{
+							v:              v.v,
+							tag:            ct.aliasTag,
+							actualTag:      ct.actualAliasTag,
+							ns:             v.str1,
+							structNs:       v.str2,
+							fieldLen:       uint8(len(cf.altName)),
+							structfieldLen: uint8(len(cf.name)),
+							messages:       cf.messages,
+							value:          current.Interface(),
+							param:          v.paramOf(ct),
+							cause:          v.causeOf(ct),
+							kind:           kind,
+							typ:            typ,
+						}
 
 					} else {
 
 						tVal := string(v.misc)[1:]
 
-						v.errs = append(v.errs,
-							&fieldError{
-								v:              v.v,
-								tag:            tVal,
-								actualTag:      tVal,
-								ns:             v.str1,
-								structNs:       v.str2,
-								fieldLen:       uint8(len(cf.altName)),
-								structfieldLen: uint8(len(cf.name)),
-								value:          current.Interface(),
-								param:          ct.param,
-								kind:           kind,
-								typ:            typ,
-							},
-						)
+						fe = &fieldError{
+							v:              v.v,
+							tag:            tVal,
+							actualTag:      tVal,
+							ns:             v.str1,
+							structNs:       v.str2,
+							fieldLen:       uint8(len(cf.altName)),
+							structfieldLen: uint8(len(cf.name)),
+							messages:       cf.messages,
+							value:          current.Interface(),
+							param:          v.paramOf(ct),
+							cause:          v.causeOf(ct),
+							kind:           kind,
+							typ:            typ,
+						}
 					}
 
+					if ct.warn {
+						fe.severity = SeverityWarning
+						v.warns = append(v.warns, fe)
+
+						ct = ct.next
+						continue OUTER
+					}
+
+					v.errs = append(v.errs, fe)
 					return
 				}
 
 				ct = ct.next
 			}
 
+		case typeGroup:
+
+			// set Field Level fields
+			v.slflParent = parent
+			v.flField = current
+			v.cf = cf
+
+			if failed := v.evalTagNode(ctx, ct.group); failed != nil {
+
+				v.str1 = string(append(ns, cf.altName...))
+
+				if v.v.hasTagNameFunc {
+					v.str2 = string(append(structNs, cf.name...))
+				} else {
+					v.str2 = v.str1
+				}
+
+				fe := &fieldError{
+					v:              v.v,
+					tag:            failed.tag,
+					actualTag:      failed.actualTag,
+					ns:             v.str1,
+					structNs:       v.str2,
+					fieldLen:       uint8(len(cf.altName)),
+					structfieldLen: uint8(len(cf.name)),
+					messages:       cf.messages,
+					value:          current.Interface(),
+					param:          failed.param,
+					cause:          failed.cause,
+					kind:           kind,
+					typ:            typ,
+				}
+
+				if ct.hasAlias {
+					fe.tag = ct.aliasTag
+				}
+
+				if !ct.warn {
+					v.errs = append(v.errs, fe)
+					return
+				}
+
+				fe.severity = SeverityWarning
+				v.warns = append(v.warns, fe)
+			}
+			ct = ct.next
+
 		default:
 
 			// set Field Level fields
@@ -441,6 +652,7 @@ OUTER:
 			v.flField = current
 			v.cf = cf
 			v.ct = ct
+			v.setCause(nil)
 
 			if !ct.fn(ctx, v) {
 
@@ -452,23 +664,29 @@ OUTER:
 					v.str2 = v.str1
 				}
 
-				v.errs = append(v.errs,
-					&fieldError{
-						v:              v.v,
-						tag:            ct.aliasTag,
-						actualTag:      ct.tag,
-						ns:             v.str1,
-						structNs:       v.str2,
-						fieldLen:       uint8(len(cf.altName)),
-						structfieldLen: uint8(len(cf.name)),
-						value:          current.Interface(),
-						param:          ct.param,
-						kind:           kind,
-						typ:            typ,
-					},
-				)
+				fe := &fieldError{
+					v:              v.v,
+					tag:            ct.aliasTag,
+					actualTag:      ct.tag,
+					ns:             v.str1,
+					structNs:       v.str2,
+					fieldLen:       uint8(len(cf.altName)),
+					structfieldLen: uint8(len(cf.name)),
+					messages:       cf.messages,
+					value:          current.Interface(),
+					param:          v.paramOf(ct),
+					cause:          v.causeOf(ct),
+					kind:           kind,
+					typ:            typ,
+				}
 
-				return
+				if !ct.warn {
+					v.errs = append(v.errs, fe)
+					return
+				}
+
+				fe.severity = SeverityWarning
+				v.warns = append(v.warns, fe)
 			}
 			ct = ct.next
 		}
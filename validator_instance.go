@@ -21,6 +21,8 @@ const (
 	tagKeySeparator       = "="
 	structOnlyTag         = "structonly"
 	noStructLevelTag      = "nostructlevel"
+	noValidateMethodTag   = "novalidatemethod"
+	validateMethodTag     = "validatemethod"
 	omitempty             = "omitempty"
 	isdefault             = "isdefault"
 	requiredWithoutAllTag = "required_without_all"
@@ -29,6 +31,10 @@ const (
 	requiredWithAllTag    = "required_with_all"
 	requiredIfTag         = "required_if"
 	requiredUnlessTag     = "required_unless"
+	excludedIfTag         = "excluded_if"
+	excludedUnlessTag     = "excluded_unless"
+	requiredWhenTag       = "required_when"
+	excludedWhenTag       = "excluded_when"
 	excludedWithoutAllTag = "excluded_without_all"
 	excludedWithoutTag    = "excluded_without"
 	excludedWithTag       = "excluded_with"
@@ -38,7 +44,10 @@ const (
 	keysTag               = "keys"
 	endKeysTag            = "endkeys"
 	requiredTag           = "required"
+	groupsTagName         = "groups"
+	modifierTagName       = "mod"
 	namespaceSeparator    = "."
+	parentNamespacePrefix = "^"
 	leftBracket           = "["
 	rightBracket          = "]"
 	restrictedTagChars    = ".[],|=+()`~!@#$%^&*\\\"/?<>{}"
@@ -49,8 +58,10 @@ const (
 var (
 	timeDurationType = reflect.TypeOf(time.Duration(0))
 	timeType         = reflect.TypeOf(time.Time{})
+	interfaceType    = reflect.TypeOf((*interface{})(nil)).Elem()
 
-	defaultCField = &cField{namesEqual: true}
+	// the methods of the values passed to Var are not called, see validatable
+	defaultCField = &cField{namesEqual: true, noValidateMethod: true, mayWrap: true}
 )
 
 // FilterFunc is the type used to filter fields using
@@ -80,12 +91,18 @@ type Validate struct {
 	hasTagNameFunc   bool
 	tagNameFunc      TagNameFunc
 	structLevelFuncs map[reflect.Type]StructLevelFuncCtx
+	structRules      map[reflect.Type]map[string]structRule
 	customFuncs      map[reflect.Type]CustomTypeFunc
 	aliases          map[string]string
 	validations      map[string]internalValidationFuncWrapper
+	modifiers        map[string]ModifierFuncCtx
 	transTagFunc     map[ut.Translator]map[string]TranslationFunc // map[<locale>]map[<tag>]TranslationFunc
 	tagCache         *tagCache
 	structCache      *structCache
+	wrappers         *sync.Map // wrapper of the types, keyed by reflect.Type, see wrapperOf
+	maxErrors        int
+	strictMaps       bool
+	clock            func() time.Time
 }
 
 // New returns a new instance of 'validate' with sane defaults.
@@ -105,8 +122,10 @@ func New() *Validate {
 		tagName:     defaultTagName,
 		aliases:     make(map[string]string, len(bakedInAliases)),
 		validations: make(map[string]internalValidationFuncWrapper, len(bakedInValidators)),
+		modifiers:   make(map[string]ModifierFuncCtx, len(bakedInModifiers)),
 		tagCache:    tc,
 		structCache: sc,
+		wrappers:    new(sync.Map),
 	}
 
 	// must copy alias validators for separate validations to be used in each validator instance
@@ -119,7 +138,7 @@ func New() *Validate {
 
 		switch k {
 		// these require that even if the value is nil that the validation should run, omitempty still overrides this behaviour
-		case requiredIfTag, requiredUnlessTag, requiredWithTag, requiredWithAllTag, requiredWithoutTag, requiredWithoutAllTag,
+		case requiredIfTag, requiredUnlessTag, excludedIfTag, excludedUnlessTag, requiredWhenTag, excludedWhenTag, requiredWithTag, requiredWithAllTag, requiredWithoutTag, requiredWithoutAllTag,
 			excludedWithTag, excludedWithAllTag, excludedWithoutTag, excludedWithoutAllTag:
 			_ = v.registerValidation(k, wrapFunc(val), true, true)
 		default:
@@ -128,6 +147,13 @@ func New() *Validate {
 		}
 	}
 
+	_ = v.registerValidation(methodTag, isMethod, true, false)
+
+	// must copy modifiers for separate modifications to be used in each instance
+	for k, val := range bakedInModifiers {
+		_ = v.registerModifier(k, wrapModifier(val), true)
+	}
+
 	v.pool = &sync.Pool{
 		New: func() interface{} {
 			return &validate{
@@ -147,6 +173,86 @@ func (v *Validate) SetTagName(name string) {
 	v.tagName = name
 }
 
+// SetMaxErrors sets the maximum number of errors collected by a single validation,
+// once reached validation stops and the errors collected so far are returned.
+// A value of 0, the default, collects all errors.
+//
+// It can be overridden per validation using WithMaxErrors or WithFailFast.
+//
+// NOTE: this method is not thread-safe it is intended that it be set prior to any validation
+func (v *Validate) SetMaxErrors(max int) {
+	if max < 0 {
+		max = 0
+	}
+	v.maxErrors = max
+}
+
+// SetFailFast stops validation at the first error when failFast is true, it is
+// the same as calling SetMaxErrors(1).
+//
+// NOTE: this method is not thread-safe it is intended that it be set prior to any validation
+func (v *Validate) SetFailFast(failFast bool) {
+	if failFast {
+		v.SetMaxErrors(1)
+	} else {
+		v.SetMaxErrors(0)
+	}
+}
+
+// SetStrictMaps sets whether Map rejects the keys of the data that have no rule,
+// reporting them using the 'unknown_key' tag.
+//
+// It can be overridden per validation using WithStrictMaps.
+//
+// NOTE: this method is not thread-safe it is intended that it be set prior to any validation
+func (v *Validate) SetStrictMaps(strict bool) {
+	v.strictMaps = strict
+}
+
+type ctxKey uint8
+
+const (
+	maxErrorsCtxKey ctxKey = iota
+	strictMapsCtxKey
+	warningsCtxKey
+	clockCtxKey
+	paramSourceCtxKey
+)
+
+// WithMaxErrors returns a copy of ctx that limits the number of errors collected by
+// the validations it is passed to, overriding the value set using SetMaxErrors.
+// A value of 0 collects all errors.
+func WithMaxErrors(ctx context.Context, max int) context.Context {
+	if max < 0 {
+		max = 0
+	}
+	return context.WithValue(ctx, maxErrorsCtxKey, max)
+}
+
+// WithFailFast returns a copy of ctx that stops the validations it is passed to at
+// the first error.
+func WithFailFast(ctx context.Context) context.Context {
+	return WithMaxErrors(ctx, 1)
+}
+
+// WithStrictMaps returns a copy of ctx that sets whether the Map validations it is
+// passed to reject the keys of the data that have no rule, overriding the value set
+// using SetStrictMaps.
+func WithStrictMaps(ctx context.Context, strict bool) context.Context {
+	return context.WithValue(ctx, strictMapsCtxKey, strict)
+}
+
+// maxErrorsFor returns the maximum number of errors to collect for a validation
+// using ctx.
+func (v *Validate) maxErrorsFor(ctx context.Context) int {
+	if ctx != nil {
+		if max, ok := ctx.Value(maxErrorsCtxKey).(int); ok {
+			return max
+		}
+	}
+	return v.maxErrors
+}
+
 // ValidateMapCtx validates a map using a map of validation rules and allows passing of contextual
 // validation validation information via context.Context.
 func (v Validate) ValidateMapCtx(ctx context.Context, data map[string]interface{}, rules map[string]interface{}) map[string]interface{} {
@@ -169,7 +275,8 @@ func (v Validate) ValidateMapCtx(ctx context.Context, data map[string]interface{
 	return errs
 }
 
-// ValidateMap validates map data form a map of tags
+// ValidateMap validates map data form a map of tags, see Map for validating
+// into arrays and returning ValidationErrors.
 func (v *Validate) ValidateMap(data map[string]interface{}, rules map[string]interface{}) map[string]interface{} {
 	return v.ValidateMapCtx(context.Background(), data, rules)
 }
@@ -209,6 +316,13 @@ func (v *Validate) RegisterValidationCtx(tag string, fn FuncCtx, callValidationE
 	return v.registerValidation(tag, fn, false, nilCheckable)
 }
 
+// RegisterValidationErr does the same as RegisterValidationCtx but accepts a FuncErr
+// validation, whose error explains the failure and is returned by the Cause method
+// of the resulting FieldError eg. to be used by its TranslationFunc.
+func (v *Validate) RegisterValidationErr(tag string, fn FuncErr, callValidationEvenIfNull ...bool) error {
+	return v.RegisterValidationCtx(tag, wrapFuncErr(fn), callValidationEvenIfNull...)
+}
+
 func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool, nilCheckable bool) error {
 	if len(tag) == 0 {
 		return errors.New("function Key cannot be empty")
@@ -226,10 +340,46 @@ func (v *Validate) registerValidation(tag string, fn FuncCtx, bakedIn bool, nilC
 	return nil
 }
 
+// RegisterModifier adds a modifier with the given tag, run by Modify and StructModify
+// for the fields using the tag in the 'mod' companion tag.
+//
+// NOTES:
+// - if the key already exists, the previous modifier function will be replaced.
+// - this method is not thread-safe it is intended that these all be registered prior to any modification
+func (v *Validate) RegisterModifier(tag string, fn ModifierFunc) error {
+	return v.RegisterModifierCtx(tag, wrapModifier(fn))
+}
+
+// RegisterModifierCtx does the same as RegisterModifier on accepts a ModifierFuncCtx modifier
+// allowing context.Context modification support.
+func (v *Validate) RegisterModifierCtx(tag string, fn ModifierFuncCtx) error {
+	return v.registerModifier(tag, fn, false)
+}
+
+func (v *Validate) registerModifier(tag string, fn ModifierFuncCtx, bakedIn bool) error {
+	if len(tag) == 0 {
+		return errors.New("function Key cannot be empty")
+	}
+
+	if fn == nil {
+		return errors.New("function cannot be empty")
+	}
+
+	if !bakedIn && strings.ContainsAny(tag, restrictedTagChars) {
+		panic(fmt.Sprintf(restrictedTagErr, tag))
+	}
+	v.modifiers[tag] = fn
+	return nil
+}
+
 // RegisterAlias registers a mapping of a single validation tag that
 // defines a common or complex set of validation(s) to simplify adding validation
 // to structs.
 //
+// The tags may use positional params $1, $2... replaced by the params of the alias,
+// separated by spaces, when it is used eg. the alias 'range' of 'min=$1,max=$2' used
+// as 'range=1 10'. They may also use other aliases.
+//
 // NOTE: this function is not thread-safe it is intended that these all be registered prior to any validation
 func (v *Validate) RegisterAlias(alias, tags string) {
 
@@ -268,6 +418,9 @@ func (v *Validate) RegisterStructValidationCtx(fn StructLevelFuncCtx, types ...i
 		}
 
 		v.structLevelFuncs[reflect.TypeOf(t)] = fn
+
+		// types having struct level validations are not unwrapped, see wrapperOf
+		v.wrappers.Delete(reflect.TypeOf(t))
 	}
 }
 
@@ -338,10 +491,128 @@ func (v *Validate) StructCtx(ctx context.Context, s interface{}) (err error) {
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
 	vd.isPartial = false
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
 
-	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
+	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil, false)
+
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
+
+	if len(vd.errs) > 0 {
+		err = vd.errs
+		vd.errs = nil
+	}
+
+	v.pool.Put(vd)
+
+	return
+}
+
+// Modify runs the modifiers of a structs exposed fields, set using the 'mod' companion tag,
+// changing the fields in place, and automatically modifies nested structs.
+//
+// eg. Email string `mod:"trim,lcase" validate:"required,email"`
+//
+// It returns InvalidValidationError for bad values passed in, s must be a pointer to a struct.
+func (v *Validate) Modify(s interface{}) error {
+	return v.ModifyCtx(context.Background(), s)
+}
+
+// ModifyCtx runs the modifiers of a structs exposed fields, set using the 'mod' companion tag,
+// changing the fields in place, and automatically modifies nested structs.
+// It also allows passing of context.Context for contextual modification information.
+//
+// It returns InvalidValidationError for bad values passed in, s must be a pointer to a struct.
+func (v *Validate) ModifyCtx(ctx context.Context, s interface{}) error {
+
+	val := reflect.ValueOf(s)
+
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct || val.Elem().Type() == timeType {
+		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	vd := v.pool.Get().(*validate)
+	vd.top = val
+
+	vd.modifyStruct(ctx, val.Elem(), val.Elem().Type())
+
+	v.pool.Put(vd)
+
+	return nil
+}
+
+// StructModify runs the modifiers of a struct using Modify and then validates it using Struct.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+func (v *Validate) StructModify(s interface{}) error {
+	return v.StructModifyCtx(context.Background(), s)
+}
+
+// StructModifyCtx runs the modifiers of a struct using ModifyCtx and then validates it using StructCtx.
+// It also allows passing of context.Context for contextual modification and validation information.
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+func (v *Validate) StructModifyCtx(ctx context.Context, s interface{}) error {
+
+	if err := v.ModifyCtx(ctx, s); err != nil {
+		return err
+	}
+
+	return v.StructCtx(ctx, s)
+}
+
+// StructGroups validates a structs exposed fields, running only the validations of the fields
+// belonging to at least one of the groups passed in, and automatically validates nested structs,
+// unless otherwise specified.
+//
+// Fields are added to groups using the 'groups' companion tag, fields without it are always validated.
+// eg. Name string `validate:"required" groups:"create,update"`
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+func (v *Validate) StructGroups(s interface{}, groups ...string) error {
+	return v.StructGroupsCtx(context.Background(), s, groups...)
+}
+
+// StructGroupsCtx validates a structs exposed fields, running only the validations of the fields
+// belonging to at least one of the groups passed in, and automatically validates nested structs,
+// unless otherwise specified and also allows passing of contextual validation information via
+// context.Context
+//
+// It returns InvalidValidationError for bad values passed in and nil or ValidationErrors as error otherwise.
+// You will need to assert the error if it's not nil eg. err.(validator.ValidationErrors) to access the array of errors.
+func (v *Validate) StructGroupsCtx(ctx context.Context, s interface{}, groups ...string) (err error) {
+	val := reflect.ValueOf(s)
+	top := val
+
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct || val.Type() == timeType {
+		return &InvalidValidationError{Type: reflect.TypeOf(s)}
+	}
+
+	// good to validate
+	vd := v.pool.Get().(*validate)
+	vd.top = top
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
+	vd.isPartial = false
+	vd.hasGroups = true
+	vd.groups = groups
+
+	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil, false)
+
+	vd.hasGroups = false
+	vd.groups = nil
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
 
 	if len(vd.errs) > 0 {
 		err = vd.errs
@@ -383,11 +654,16 @@ func (v *Validate) StructFilteredCtx(ctx context.Context, s interface{}, fn Filt
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
 	vd.isPartial = true
 	vd.ffn = fn
 	// vd.hasExcludes = false // only need to reset in StructPartial and StructExcept
 
-	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil)
+	vd.validateStruct(ctx, top, val, val.Type(), vd.ns[0:0], vd.actualNs[0:0], nil, false)
+
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
 
 	if len(vd.errs) > 0 {
 		err = vd.errs
@@ -431,6 +707,8 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
 	vd.isPartial = true
 	vd.ffn = nil
 	vd.hasExcludes = false
@@ -477,7 +755,10 @@ func (v *Validate) StructPartialCtx(ctx context.Context, s interface{}, fields .
 		}
 	}
 
-	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
+	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil, false)
+
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
 
 	if len(vd.errs) > 0 {
 		err = vd.errs
@@ -521,6 +802,8 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 	// good to validate
 	vd := v.pool.Get().(*validate)
 	vd.top = top
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
 	vd.isPartial = true
 	vd.ffn = nil
 	vd.hasExcludes = true
@@ -542,7 +825,10 @@ func (v *Validate) StructExceptCtx(ctx context.Context, s interface{}, fields ..
 		vd.includeExclude[string(vd.misc)] = struct{}{}
 	}
 
-	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil)
+	vd.validateStruct(ctx, top, val, typ, vd.ns[0:0], vd.actualNs[0:0], nil, false)
+
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
 
 	if len(vd.errs) > 0 {
 		err = vd.errs
@@ -594,9 +880,14 @@ func (v *Validate) VarCtx(ctx context.Context, field interface{}, tag string) (e
 	val := reflect.ValueOf(field)
 	vd := v.pool.Get().(*validate)
 	vd.top = val
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
 	vd.isPartial = false
 	vd.traverseField(ctx, val, val, vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
 
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
+
 	if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
@@ -646,9 +937,14 @@ func (v *Validate) VarWithValueCtx(ctx context.Context, field interface{}, other
 	otherVal := reflect.ValueOf(other)
 	vd := v.pool.Get().(*validate)
 	vd.top = otherVal
+	vd.maxErrs = v.maxErrorsFor(ctx)
+	vd.clock = v.clockFor(ctx)
 	vd.isPartial = false
 	vd.traverseField(ctx, otherVal, reflect.ValueOf(field), vd.ns[0:0], vd.actualNs[0:0], defaultCField, ctag)
 
+	vd.limitErrs()
+	vd.flushWarnings(ctx)
+
 	if len(vd.errs) > 0 {
 		err = vd.errs
 		vd.errs = nil
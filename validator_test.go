@@ -7,7 +7,12 @@ import (
 	"database/sql/driver"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http/httptest"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -18,6 +23,7 @@ import (
 	"github.com/haiyiyun/validator/locales/en"
 	"github.com/haiyiyun/validator/locales/fr"
 	"github.com/haiyiyun/validator/locales/nl"
+	"github.com/haiyiyun/validator/rules"
 	ut "github.com/haiyiyun/validator/universal-translator"
 )
 
@@ -10069,6 +10075,167 @@ func TestRequiredUnless(t *testing.T) {
 	_ = validate.Struct(test3)
 }
 
+func TestExcludedIf(t *testing.T) {
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	test := struct {
+		Inner   *Inner
+		FieldE  string  `validate:"omitempty" json:"field_e"`
+		FieldER string  `validate:"excluded_if=FieldE test" json:"field_er"`
+		Field1  string  `validate:"excluded_if=FieldE other" json:"field_1"`
+		Field2  *string `validate:"excluded_if=Inner.Field test" json:"field_2"`
+		Field3  int     `validate:"excluded_if=FieldE test Field1 other" json:"field_3"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		FieldE: "test",
+		Field1: "test",
+		Field3: 1,
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Inner   *Inner
+		FieldE  string  `validate:"omitempty" json:"field_e"`
+		FieldER string  `validate:"excluded_if=FieldE test" json:"field_er"`
+		Field1  string  `validate:"excluded_if=FieldE test" json:"field_1"`
+		Field2  *string `validate:"excluded_if=Inner.Field test" json:"field_2"`
+		Field3  *string `validate:"excluded_if=Inner.Field test" json:"field_3"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		FieldE: "test",
+		Field1: "test",
+		Field2: &fieldVal,
+	}
+
+	errs = validate.Struct(test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "Field1", "Field1", "Field1", "Field1", "excluded_if")
+	AssertError(t, errs, "Field2", "Field2", "Field2", "Field2", "excluded_if")
+
+	PanicMatches(t, func() {
+		_ = validate.Struct(struct {
+			Field1 string `validate:"excluded_if=Field2" json:"field_1"`
+			Field2 string
+		}{})
+	}, "Bad param number for excluded_if Field1")
+}
+
+func TestExcludedUnless(t *testing.T) {
+	type Inner struct {
+		Field *string
+	}
+
+	fieldVal := "test"
+	test := struct {
+		Inner   *Inner
+		FieldE  string  `validate:"omitempty" json:"field_e"`
+		FieldER string  `validate:"excluded_unless=FieldE other" json:"field_er"`
+		Field1  string  `validate:"excluded_unless=FieldE test" json:"field_1"`
+		Field2  *string `validate:"excluded_unless=Inner.Field test" json:"field_2"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		FieldE: "test",
+		Field1: "test",
+		Field2: &fieldVal,
+	}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test2 := struct {
+		Inner  *Inner
+		FieldE string  `validate:"omitempty" json:"field_e"`
+		Field1 string  `validate:"excluded_unless=FieldE other" json:"field_1"`
+		Field2 *string `validate:"excluded_unless=Inner.Field other" json:"field_2"`
+		Field3 int     `validate:"excluded_unless=FieldE test Field1 other" json:"field_3"`
+		Field4 string  `validate:"excluded_unless=Inner.Field other" json:"field_4"`
+	}{
+		Inner:  &Inner{Field: &fieldVal},
+		FieldE: "test",
+		Field1: "test",
+		Field2: &fieldVal,
+		Field3: 1,
+	}
+
+	errs = validate.Struct(test2)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 3)
+	AssertError(t, errs, "Field1", "Field1", "Field1", "Field1", "excluded_unless")
+	AssertError(t, errs, "Field2", "Field2", "Field2", "Field2", "excluded_unless")
+	AssertError(t, errs, "Field3", "Field3", "Field3", "Field3", "excluded_unless")
+
+	PanicMatches(t, func() {
+		_ = validate.Struct(struct {
+			Field1 string `validate:"excluded_unless=Field2" json:"field_1"`
+			Field2 string
+		}{})
+	}, "Bad param number for excluded_unless Field1")
+}
+
+func TestConditionalParentNamespace(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price int    `validate:"required_if=^Kind paid"`
+		Note  string `validate:"excluded_if=^^Mode readonly"`
+	}
+
+	type Order struct {
+		Kind  string
+		Items []Item `validate:"dive"`
+		Main  Item
+	}
+
+	type Cart struct {
+		Mode   string
+		Order  Order
+		Coupon string `validate:"excluded_unless=Order.Kind free"`
+		Total  int    `validate:"required_when=Order.Kind=='paid' && ^Missing==nil"`
+	}
+
+	validate := New()
+
+	cart := Cart{
+		Mode: "readonly",
+		Order: Order{
+			Kind:  "paid",
+			Items: []Item{{Name: "a", Price: 1}, {Name: "b", Note: "gift"}},
+			Main:  Item{Name: "c"},
+		},
+		Coupon: "FREE",
+	}
+
+	errs := validate.Struct(cart)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 5)
+	AssertError(t, errs, "Cart.Order.Items[1].Price", "Cart.Order.Items[1].Price", "Price", "Price", "required_if")
+	AssertError(t, errs, "Cart.Order.Items[1].Note", "Cart.Order.Items[1].Note", "Note", "Note", "excluded_if")
+	AssertError(t, errs, "Cart.Order.Main.Price", "Cart.Order.Main.Price", "Price", "Price", "required_if")
+	AssertError(t, errs, "Cart.Coupon", "Cart.Coupon", "Coupon", "Coupon", "excluded_unless")
+	AssertError(t, errs, "Cart.Total", "Cart.Total", "Total", "Total", "required_when")
+
+	cart.Mode = ""
+	cart.Order.Kind = "free"
+
+	errs = validate.Struct(cart)
+	Equal(t, errs, nil)
+}
+
 func TestRequiredWith(t *testing.T) {
 	type Inner struct {
 		Field *string
@@ -11418,3 +11585,2855 @@ func TestPostCodeByIso3166Alpha2Field_InvalidKind(t *testing.T) {
 	_ = New().Struct(test{"ABC", 123, false})
 	t.Errorf("Didn't panic as expected")
 }
+
+func TestJSONSchema(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"required,max=64"`
+		Zip    string `json:"zip" validate:"len=5,numeric"`
+	}
+
+	type User struct {
+		Name      string            `json:"name" validate:"required,min=3,max=32"`
+		Email     string            `json:"email" validate:"required,email"`
+		ID        string            `json:"id" validate:"omitempty,uuid"`
+		Age       int               `json:"age" validate:"gte=18,lt=130"`
+		Role      string            `json:"role" validate:"oneof=admin user"`
+		Contact   string            `json:"contact" validate:"email|e164"`
+		Password  string            `json:"password" validate:"required"`
+		Confirm   string            `json:"confirm" validate:"eqfield=Password"`
+		Tags      []string          `json:"tags" validate:"max=5,dive,min=1"`
+		Labels    map[string]string `json:"labels" validate:"dive,keys,alpha,endkeys,required"`
+		Address   Address           `json:"address"`
+		Addresses []*Address        `json:"addresses" validate:"required,dive"`
+		Birthday  *time.Time        `json:"birthday"`
+		Score     int               `json:"score" validate:"required,ne=5"`
+		Nickname  *string           `json:"nickname" validate:"omitempty,min=2"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	s, err := validate.JSONSchema(&User{})
+	Equal(t, err, nil)
+	Equal(t, s.Schema, "https://json-schema.org/draft/2020-12/schema")
+	Equal(t, s.Title, "User")
+	Equal(t, s.Required, []string{"name", "email", "password", "addresses", "score"})
+
+	name := s.Properties["name"]
+	Equal(t, name.Type, SchemaType{"string"})
+	Equal(t, *name.MinLength, int64(3))
+	Equal(t, *name.MaxLength, int64(32))
+
+	Equal(t, s.Properties["email"].Format, "email")
+
+	// omitempty fields are either the zero value or pass their validations
+	id := s.Properties["id"]
+	Equal(t, len(id.AnyOf), 2)
+	Equal(t, id.AnyOf[0].Const, "")
+	Equal(t, id.AnyOf[1].Type, SchemaType{"string"})
+	Equal(t, id.AnyOf[1].Format, "uuid")
+
+	nickname := s.Properties["nickname"]
+	Equal(t, len(nickname.AnyOf), 2)
+	Equal(t, nickname.AnyOf[0].Type, SchemaType{"null"})
+	Equal(t, nickname.AnyOf[1].Type, SchemaType{"string"})
+	Equal(t, *nickname.AnyOf[1].MinLength, int64(2))
+
+	score := s.Properties["score"]
+	Equal(t, score.Not.Const, 0)
+	Equal(t, len(score.AllOf), 1)
+	Equal(t, score.AllOf[0].Not.Const, int64(5))
+
+	age := s.Properties["age"]
+	Equal(t, age.Minimum, json.Number("18"))
+	Equal(t, age.ExclusiveMaximum, json.Number("130"))
+
+	Equal(t, s.Properties["role"].Enum, []interface{}{"admin", "user"})
+
+	contact := s.Properties["contact"]
+	Equal(t, len(contact.AnyOf), 2)
+	Equal(t, contact.AnyOf[0].Format, "email")
+	Equal(t, contact.AnyOf[1].Pattern, e164RegexString)
+
+	Equal(t, s.Properties["confirm"].Unsupported, []string{"eqfield=Password"})
+
+	tags := s.Properties["tags"]
+	Equal(t, tags.Type, SchemaType{"array", "null"})
+	Equal(t, *tags.MaxItems, int64(5))
+	Equal(t, *tags.Items.MinLength, int64(1))
+
+	labels := s.Properties["labels"]
+	Equal(t, labels.PropertyNames.Pattern, alphaRegexString)
+	Equal(t, *labels.AdditionalProperties.MinLength, int64(1))
+
+	Equal(t, s.Properties["address"].Ref, "#/$defs/Address")
+	Equal(t, s.Properties["addresses"].Items.Ref, "#/$defs/Address")
+	Equal(t, s.Properties["birthday"].Format, "date-time")
+
+	addr := s.Defs["Address"]
+	NotEqual(t, addr, nil)
+	Equal(t, addr.Required, []string{"street"})
+	Equal(t, *addr.Properties["zip"].MinLength, int64(5))
+	Equal(t, addr.Properties["zip"].Pattern, numericRegexString)
+
+	b, err := json.Marshal(s)
+	Equal(t, err, nil)
+	Equal(t, strings.Contains(string(b), `"x-unsupported-tags":["eqfield=Password"]`), true)
+	Equal(t, strings.Contains(string(b), `"id":{"anyOf":[{"const":""},{"type":"string","format":"uuid"}]}`), true)
+
+	_, err = validate.JSONSchema("not a struct")
+	NotEqual(t, err, nil)
+	_, ok := err.(*InvalidValidationError)
+	Equal(t, ok, true)
+
+	type Node struct {
+		Value    int     `validate:"required"`
+		Children []*Node `validate:"dive"`
+	}
+
+	s, err = validate.JSONSchema(Node{})
+	Equal(t, err, nil)
+	Equal(t, s.Properties["Children"].Items.Ref, "#/$defs/Node")
+	NotEqual(t, s.Defs["Node"], nil)
+	Equal(t, s.Properties["Value"].Not.Const, 0)
+
+	// warnings are left out
+	type Account struct {
+		Password string   `validate:"required,warn(min=12,containsany=!@#),max=64"`
+		Nick     *string  `validate:"warn(required),omitempty,warn(alpha|numeric),min=2"`
+		Tags     []string `validate:"dive,warn(max=3)"`
+	}
+
+	s, err = validate.JSONSchema(Account{})
+	Equal(t, err, nil)
+	Equal(t, s.Required, []string{"Password"})
+
+	password := s.Properties["Password"]
+	Equal(t, *password.MinLength, int64(1))
+	Equal(t, *password.MaxLength, int64(64))
+	Equal(t, password.Pattern, "")
+
+	nick := s.Properties["Nick"]
+	Equal(t, len(nick.AnyOf), 2)
+	Equal(t, len(nick.AnyOf[1].AnyOf), 0)
+	Equal(t, *nick.AnyOf[1].MinLength, int64(2))
+
+	Equal(t, s.Properties["Tags"].Items.MaxLength == nil, true)
+
+	b, err = json.Marshal(s)
+	Equal(t, err, nil)
+	Equal(t, strings.Contains(string(b), "x-unsupported-tags"), false)
+}
+
+func TestOpenAPIComponents(t *testing.T) {
+	type Address struct {
+		Zip string `json:"zip" validate:"required,len=5"`
+	}
+
+	type CreateUserRequest struct {
+		Name      string             `json:"name" validate:"required"`
+		Roles     []string           `json:"roles" validate:"min=1,dive,oneof=admin user"`
+		Addresses map[string]Address `json:"addresses" validate:"dive,keys,min=2,endkeys"`
+		CreatedAt time.Time          `json:"created_at"`
+		Age       int                `json:"age" validate:"omitempty,min=18"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	c, err := validate.OpenAPIComponents(CreateUserRequest{})
+	Equal(t, err, nil)
+	Equal(t, len(c.Schemas), 2)
+
+	req := c.Schemas["CreateUserRequest"]
+	NotEqual(t, req, nil)
+	Equal(t, req.Schema, "")
+	Equal(t, req.Required, []string{"name"})
+	Equal(t, req.Properties["roles"].Items.Enum, []interface{}{"admin", "user"})
+	Equal(t, *req.Properties["addresses"].PropertyNames.MinLength, int64(2))
+	Equal(t, req.Properties["addresses"].AdditionalProperties.Ref, "#/components/schemas/Address")
+	Equal(t, req.Properties["created_at"].Format, "date-time")
+	Equal(t, c.Schemas["Address"].Required, []string{"zip"})
+
+	age := req.Properties["age"]
+	Equal(t, len(age.AnyOf), 2)
+	Equal(t, age.AnyOf[0].Const, 0)
+	Equal(t, age.AnyOf[1].Minimum, json.Number("18"))
+
+	b, err := c.JSON()
+	Equal(t, err, nil)
+	Equal(t, strings.HasPrefix(string(b), "{\n  \"schemas\": {"), true)
+
+	y, err := c.YAML()
+	Equal(t, err, nil)
+	Equal(t, strings.HasPrefix(string(y), "\"schemas\":\n  \"Address\":\n    \"type\": \"object\"\n"), true)
+	Equal(t, strings.Contains(string(y), "    \"required\":\n      - \"zip\"\n"), true)
+	Equal(t, strings.Contains(string(y), "\"$ref\": \"#/components/schemas/Address\""), true)
+	Equal(t, strings.Contains(string(y), "      \"age\":\n        \"anyOf\":\n          - \"const\": 0\n          - \"type\": \"integer\"\n            \"minimum\": 18\n"), true)
+
+	_, err = validate.OpenAPIComponents(struct{ Name string }{})
+	NotEqual(t, err, nil)
+}
+
+func TestValidationErrorsJSON(t *testing.T) {
+	type Inner struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	type Test struct {
+		Age   int    `json:"age" validate:"gte=18"`
+		Inner *Inner `json:"inner"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	err := validate.Struct(Test{Age: 10, Inner: &Inner{}})
+	NotEqual(t, err, nil)
+
+	ve := err.(ValidationErrors)
+
+	b, jerr := json.Marshal(ve)
+	Equal(t, jerr, nil)
+	Equal(t, string(b), `[{"namespace":"Test.age","structNamespace":"Test.Age","field":"age","structField":"Age","tag":"gte","actualTag":"gte","param":"18","kind":"int","type":"int","message":"Key: 'Test.age' Error:Field validation for 'age' failed on the 'gte' tag"},`+
+		`{"namespace":"Test.inner.name","structNamespace":"Test.Inner.Name","field":"name","structField":"Name","tag":"required","actualTag":"required","param":"","kind":"string","type":"string","message":"Key: 'Test.inner.name' Error:Field validation for 'name' failed on the 'required' tag"}]`)
+
+	b, jerr = json.Marshal(ve[0])
+	Equal(t, jerr, nil)
+	Equal(t, strings.HasPrefix(string(b), `{"namespace":"Test.age"`), true)
+
+	en := en.New()
+	uni := ut.New(en, en)
+	trans, _ := uni.GetTranslator("en")
+
+	_ = validate.RegisterTranslation("gte", trans, func(ut ut.Translator) error {
+		return ut.Add("gte", "{0} must be {1} or greater", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		t, _ := ut.T(fe.Tag(), fe.Field(), fe.Param())
+		return t
+	})
+
+	docs := ve.Documents(trans, true)
+	Equal(t, len(docs), 2)
+	Equal(t, docs[0].Message, "age must be 18 or greater")
+	Equal(t, docs[0].Value, 10)
+
+	w := httptest.NewRecorder()
+	Equal(t, ve.WriteProblem(w, trans, false), nil)
+	Equal(t, w.Code, 422)
+	Equal(t, w.Header().Get("Content-Type"), ProblemContentType)
+
+	var problem ProblemDetails
+	Equal(t, json.Unmarshal(w.Body.Bytes(), &problem), nil)
+	Equal(t, problem.Title, "Validation Failed")
+	Equal(t, problem.Status, 422)
+	Equal(t, len(problem.Errors), 2)
+	Equal(t, problem.Errors[0].Message, "age must be 18 or greater")
+	Equal(t, problem.Errors[0].Value, nil)
+	Equal(t, problem.Errors[1].Namespace, "Test.inner.name")
+}
+
+func TestMaxErrors(t *testing.T) {
+	type Inner struct {
+		Name string `validate:"required"`
+	}
+
+	type Test struct {
+		A      string   `validate:"required"`
+		B      string   `validate:"required"`
+		Items  []string `validate:"dive,required"`
+		Inners []Inner  `validate:"dive"`
+	}
+
+	test := Test{Items: make([]string, 10000), Inners: make([]Inner, 100)}
+
+	validate := New()
+
+	errs := validate.Struct(test)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 10102)
+
+	validate.SetFailFast(true)
+	errs = validate.Struct(test)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "Test.A", "Test.A", "A", "A", "required")
+
+	validate.SetMaxErrors(5)
+	errs = validate.Struct(test)
+	Equal(t, len(errs.(ValidationErrors)), 5)
+	AssertError(t, errs, "Test.Items[2]", "Test.Items[2]", "Items[2]", "Items[2]", "required")
+
+	// per call override
+	errs = validate.StructCtx(WithMaxErrors(context.Background(), 0), test)
+	Equal(t, len(errs.(ValidationErrors)), 10102)
+
+	validate.SetFailFast(false)
+	errs = validate.StructCtx(WithFailFast(context.Background()), test)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	errs = validate.StructCtx(WithMaxErrors(context.Background(), 3), &Test{A: "a", B: "b", Inners: make([]Inner, 10)})
+	Equal(t, len(errs.(ValidationErrors)), 3)
+	AssertError(t, errs, "Test.Inners[2].Name", "Test.Inners[2].Name", "Name", "Name", "required")
+
+	errs = validate.VarCtx(WithFailFast(context.Background()), map[string]string{"a": "", "b": ""}, "dive,required")
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	// struct level validations reporting several errors are truncated
+	validate = New()
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		sl.ReportError("", "X", "X", "x", "")
+		sl.ReportError("", "Y", "Y", "y", "")
+	}, Inner{})
+
+	errs = validate.StructCtx(WithMaxErrors(context.Background(), 2), Inner{Name: "ok"})
+	Equal(t, len(errs.(ValidationErrors)), 2)
+
+	errs = validate.StructCtx(WithFailFast(context.Background()), Inner{Name: "ok"})
+	Equal(t, len(errs.(ValidationErrors)), 1)
+}
+
+func TestStructGroups(t *testing.T) {
+	type Address struct {
+		Street string `validate:"required" groups:"create"`
+		City   string `validate:"required"`
+	}
+
+	type User struct {
+		ID      int      `validate:"required" groups:"update"`
+		Name    string   `validate:"required" groups:"create, update"`
+		Role    string   `validate:"required" groups:"admin"`
+		Email   string   `validate:"required"`
+		Address *Address `validate:"required"`
+	}
+
+	validate := New()
+
+	user := &User{Address: &Address{}}
+
+	errs := validate.Struct(user)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 6)
+
+	errs = validate.StructGroups(user, "create")
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "required")
+	AssertError(t, errs, "User.Email", "User.Email", "Email", "Email", "required")
+	AssertError(t, errs, "User.Address.Street", "User.Address.Street", "Street", "Street", "required")
+	AssertError(t, errs, "User.Address.City", "User.Address.City", "City", "City", "required")
+
+	errs = validate.StructGroups(user, "update")
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	AssertError(t, errs, "User.ID", "User.ID", "ID", "ID", "required")
+
+	errs = validate.StructGroupsCtx(context.Background(), user, "update", "admin")
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 5)
+	AssertError(t, errs, "User.Role", "User.Role", "Role", "Role", "required")
+
+	// only ungrouped fields
+	errs = validate.StructGroups(user)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+
+	// groups do not leak into the following validations
+	errs = validate.Struct(user)
+	Equal(t, len(errs.(ValidationErrors)), 6)
+
+	errs = validate.StructGroups(1, "create")
+	_, ok := errs.(*InvalidValidationError)
+	Equal(t, ok, true)
+}
+
+func TestRequiredWhen(t *testing.T) {
+	type Inner struct {
+		Kind string
+	}
+
+	type Test struct {
+		Status   string
+		Age      int
+		Guardian string
+		Tags     []string
+		Inner    *Inner
+		Field1   string `validate:"required_when=Status=='active' && (Age>=18 || Guardian!='')"`
+		Field2   *int   `validate:"required_when=Status in ('active' 'pending')"`
+		Field3   string `validate:"required_when=!(Age < 18) && Tags"`
+		Field4   string `validate:"required_when=Inner.Kind not in ['a' 'b']"`
+		Field5   string `validate:"excluded_when=Inner==nil || Status=='closed'"`
+		Field6   string `validate:"omitempty,required_when=Age>100"`
+	}
+
+	validate := New()
+
+	test := Test{Status: "inactive", Age: 10, Field5: "x"}
+	errs := validate.Struct(test)
+	NotEqual(t, errs, nil)
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	// nil Inner.Kind is not in the list
+	AssertError(t, errs, "Test.Field4", "Test.Field4", "Field4", "Field4", "required_when")
+	AssertError(t, errs, "Test.Field5", "Test.Field5", "Field5", "Field5", "excluded_when")
+
+	test = Test{Status: "active", Age: 18, Tags: []string{"a"}, Inner: &Inner{Kind: "c"}}
+	errs = validate.Struct(test)
+	NotEqual(t, errs, nil)
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	AssertError(t, errs, "Test.Field1", "Test.Field1", "Field1", "Field1", "required_when")
+	AssertError(t, errs, "Test.Field2", "Test.Field2", "Field2", "Field2", "required_when")
+	AssertError(t, errs, "Test.Field3", "Test.Field3", "Field3", "Field3", "required_when")
+	AssertError(t, errs, "Test.Field4", "Test.Field4", "Field4", "Field4", "required_when")
+
+	one := 1
+	test = Test{Status: "active", Age: 12, Guardian: "Mum", Inner: &Inner{Kind: "a"}, Field1: "x", Field2: &one}
+	errs = validate.Struct(test)
+	Equal(t, errs, nil)
+
+	test = Test{Status: "pending", Age: 12, Inner: &Inner{Kind: "b"}, Field2: &one}
+	errs = validate.Struct(test)
+	Equal(t, errs, nil)
+
+	PanicMatches(t, func() {
+		_ = validate.Var("", "required_when=Status=='active' &&")
+	}, "Invalid expression 'Status=='active' &&' on field '': unexpected end of expression")
+
+	PanicMatches(t, func() {
+		_ = validate.Var("", "required_when=(A==1")
+	}, "Invalid expression '(A==1' on field '': missing ')'")
+
+	PanicMatches(t, func() {
+		_ = validate.Var("", "excluded_when=A=='b")
+	}, "Invalid expression 'A=='b' on field '': unterminated string")
+}
+
+func TestModify(t *testing.T) {
+	type Address struct {
+		City    string `mod:"trim,ucase"`
+		Country string `mod:"default=NZ"`
+	}
+
+	type User struct {
+		Name      string        `mod:"trim" validate:"required"`
+		Email     string        `mod:"trim,lcase" validate:"required,email"`
+		Padded    string        `mod:"ltrim"`
+		Accented  string        `mod:"nfc"`
+		Age       int           `mod:"default=18" validate:"gte=18"`
+		Ratio     float64       `mod:"default=0.5"`
+		Active    *bool         `mod:"default=true"`
+		Timeout   time.Duration `mod:"default=1m"`
+		Tags      []string      `mod:"default=ignored"`
+		Title     *string       `mod:"trim"`
+		Address   Address
+		Addresses []*Address
+		Extra     string `mod:"default=a0x2Cb"`
+	}
+
+	title := "  Mr  "
+
+	u := User{
+		Name:      "  Joey ",
+		Email:     " Joey@Example.COM ",
+		Padded:    "  left  ",
+		Accented:  "cafe\u0301",
+		Age:       21,
+		Title:     &title,
+		Address:   Address{City: " wellington "},
+		Addresses: []*Address{{City: "auckland", Country: "AU"}, nil},
+	}
+
+	validate := New()
+
+	errs := validate.Modify(&u)
+	Equal(t, errs, nil)
+	Equal(t, u.Name, "Joey")
+	Equal(t, u.Email, "joey@example.com")
+	Equal(t, u.Padded, "left  ")
+	Equal(t, u.Accented, "caf\u00e9")
+	Equal(t, u.Age, 21)
+	Equal(t, u.Ratio, 0.5)
+	NotEqual(t, u.Active, nil)
+	Equal(t, *u.Active, true)
+	Equal(t, u.Timeout, time.Minute)
+	Equal(t, len(u.Tags), 0)
+	Equal(t, *u.Title, "Mr")
+	Equal(t, u.Address.City, "WELLINGTON")
+	Equal(t, u.Address.Country, "NZ")
+	Equal(t, u.Addresses[0].City, "AUCKLAND")
+	Equal(t, u.Addresses[0].Country, "AU")
+	Equal(t, u.Extra, "a,b")
+
+	errs = validate.Modify(u)
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil validator.User)")
+
+	errs = validate.Modify((*User)(nil))
+	NotEqual(t, errs, nil)
+
+	u2 := User{Name: "   ", Email: " JOEY@EXAMPLE.COM"}
+
+	errs = validate.StructModify(&u2)
+	NotEqual(t, errs, nil)
+	Equal(t, u2.Email, "joey@example.com")
+	Equal(t, u2.Age, 18)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "required")
+
+	// custom modifiers and RegisterTagNameFunc naming
+	validate = New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	var names []string
+
+	err := validate.RegisterModifier("slug", func(fl FieldLevel) {
+		names = append(names, fl.FieldName())
+		fl.Field().SetString(strings.Replace(strings.ToLower(fl.Field().String()), " ", fl.Param(), -1))
+	})
+	Equal(t, err, nil)
+
+	err = validate.RegisterModifierCtx("ctx", func(ctx context.Context, fl FieldLevel) {
+		fl.Field().SetString(ctx.Value(&names).(string))
+	})
+	Equal(t, err, nil)
+
+	type Post struct {
+		Slug string `json:"slug" mod:"trim,slug=-" validate:"required"`
+		From string `json:"from" mod:"ctx"`
+	}
+
+	p := Post{Slug: " Hello World "}
+
+	errs = validate.StructModifyCtx(context.WithValue(context.Background(), &names, "ctx"), &p)
+	Equal(t, errs, nil)
+	Equal(t, p.Slug, "hello-world")
+	Equal(t, p.From, "ctx")
+	Equal(t, names, []string{"slug"})
+
+	Equal(t, validate.RegisterModifier("", nil).Error(), "function Key cannot be empty")
+	Equal(t, validate.RegisterModifier("none", nil).Error(), "function cannot be empty")
+
+	PanicMatches(t, func() {
+		_ = validate.RegisterModifier("no,comma", func(fl FieldLevel) {})
+	}, "Tag 'no,comma' either contains restricted characters or is the same as a restricted tag needed for normal operation")
+
+	PanicMatches(t, func() {
+		_ = validate.Modify(&struct {
+			Field string `mod:"unknown"`
+		}{})
+	}, "Undefined modifier function 'unknown' on field 'Field'")
+
+	PanicMatches(t, func() {
+		_ = validate.Modify(&struct {
+			Field string `mod:"trim,"`
+		}{})
+	}, "Invalid modifier tag on field 'Field'")
+}
+
+type methodAddress struct {
+	City string `validate:"required"`
+	Zip  string
+}
+
+func (a methodAddress) Validate() error {
+	if a.City == "Nowhere" {
+		return errors.New("unknown city")
+	}
+	return nil
+}
+
+type methodRange struct {
+	Min int
+	Max int
+}
+
+func (r *methodRange) Validate(ctx context.Context) error {
+	if r.Min > r.Max {
+		return ValidationErrors{&fieldError{tag: "ltefield", actualTag: "ltefield", ns: "Min", structNs: "Min", fieldLen: 3, structfieldLen: 3, param: "Max"}}
+	}
+	return nil
+}
+
+type methodTags []string
+
+func (t methodTags) Validate() error {
+	if len(t) > 2 {
+		return errors.New("too many tags")
+	}
+	return nil
+}
+
+type methodCode string
+
+func (c methodCode) Validate() error {
+	if strings.ToUpper(string(c)) != string(c) {
+		return fmt.Errorf("%s is not upper case", string(c))
+	}
+	return nil
+}
+
+type methodOrder struct {
+	Home    methodAddress
+	Work    *methodAddress `validate:"novalidatemethod"`
+	Range   methodRange
+	Tags    methodTags
+	Codes   map[string]methodCode `validate:"dive"`
+	Skipped []methodCode          `validate:"novalidatemethod,dive,required"`
+}
+
+type methodRejectKey struct{}
+
+func (o *methodOrder) Validate(ctx context.Context) error {
+	if ctx.Value(methodRejectKey{}) != nil {
+		return errors.New("order rejected")
+	}
+	return nil
+}
+
+func TestValidateMethod(t *testing.T) {
+	validate := New()
+
+	o := &methodOrder{
+		Home:    methodAddress{City: "Nowhere"},
+		Work:    &methodAddress{City: "Nowhere"},
+		Range:   methodRange{Min: 5, Max: 1},
+		Tags:    methodTags{"a", "b", "c"},
+		Codes:   map[string]methodCode{"a": "ABC", "b": "abc"},
+		Skipped: []methodCode{"abc"},
+	}
+
+	errs := validate.Struct(o)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	AssertError(t, errs, "methodOrder.Home", "methodOrder.Home", "Home", "Home", "validatemethod")
+	AssertError(t, errs, "methodOrder.Range.Min", "methodOrder.Range.Min", "Min", "Min", "ltefield")
+	AssertError(t, errs, "methodOrder.Tags", "methodOrder.Tags", "Tags", "Tags", "validatemethod")
+	AssertError(t, errs, "methodOrder.Codes[b]", "methodOrder.Codes[b]", "Codes[b]", "Codes[b]", "validatemethod")
+
+	fe := getError(errs, "methodOrder.Home", "methodOrder.Home")
+	Equal(t, fe.Param(), "unknown city")
+	Equal(t, fe.Value(), methodAddress{City: "Nowhere"})
+	Equal(t, fe.Kind(), reflect.Struct)
+
+	fe = getError(errs, "methodOrder.Codes[b]", "methodOrder.Codes[b]")
+	Equal(t, fe.Param(), "abc is not upper case")
+
+	// context aware methods of nested structs
+	ctx := context.WithValue(context.Background(), methodRejectKey{}, true)
+
+	type Orders struct {
+		Order methodOrder
+	}
+
+	errs = validate.StructCtx(ctx, &Orders{Order: methodOrder{Home: methodAddress{City: "Somewhere"}}})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "Orders.Order", "Orders.Order", "Order", "Order", "validatemethod")
+
+	// pointer receivers are not called for values that cannot be addressed
+	errs = validate.StructCtx(ctx, Orders{Order: methodOrder{Home: methodAddress{City: "Somewhere"}}})
+	Equal(t, errs, nil)
+
+	// the methods of the values validated are not called, they may validate themselves
+	errs = validate.StructCtx(ctx, &methodOrder{Home: methodAddress{City: "Somewhere"}})
+	Equal(t, errs, nil)
+
+	errs = validate.Struct(methodAddress{City: "Nowhere"})
+	Equal(t, errs, nil)
+
+	errs = validate.Var(methodTags{"a", "b", "c"}, "required")
+	Equal(t, errs, nil)
+
+	errs = validate.Var(methodTags{"a", "b", "c"}, "max=2")
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "", "", "", "", "max")
+
+	errs = selfValidating{}.Validate()
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "selfValidating.Name", "selfValidating.Name", "Name", "Name", "required")
+
+	Equal(t, selfValidating{Name: "a"}.Validate(), nil)
+
+	// the errors of methods validating their value are relative to the value, those
+	// already reported for the value are dropped
+	type User struct {
+		Self  selfValidating
+		Selfs []selfValidating `validate:"dive"`
+	}
+
+	errs = validate.Struct(User{Selfs: []selfValidating{{Name: "a"}, {}}})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "User.Self.Name", "User.Self.Name", "Name", "Name", "required")
+	AssertError(t, errs, "User.Selfs[1].Name", "User.Selfs[1].Name", "Name", "Name", "required")
+
+	type Inner struct {
+		Errs methodErrors
+	}
+
+	errs = validate.Struct(Inner{Errs: methodErrors{errs: ValidationErrors{
+		&fieldError{tag: "min", actualTag: "min", ns: "Root.Tags[1]", structNs: "Root.Tags[1]", fieldLen: 7, structfieldLen: 7, rootLen: 5, indexes: []int{9}},
+	}}})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, errs, "Inner.Errs.Tags[1]", "Inner.Errs.Tags[1]", "Tags[1]", "Tags[1]", "min")
+	Equal(t, ve[0].JSONPointer(), "/Errs/Tags/1")
+}
+
+var selfValidator = New()
+
+type selfValidating struct {
+	Name string `validate:"required"`
+}
+
+func (s selfValidating) Validate() error {
+	return selfValidator.Struct(s)
+}
+
+func TestCheck(t *testing.T) {
+	type Inner struct {
+		Name  string `validate:"required,min=1"`
+		Price int    `validate:"gtfield=Cost"`
+	}
+
+	type Good struct {
+		Name     string            `validate:"required,max=10"`
+		Confirm  string            `validate:"eqfield=Name"`
+		Role     string            `validate:"eq=admin"`
+		Guest    string            `validate:"ne=guest,ne=$Price"`
+		Active   bool              `validate:"eq=true"`
+		Timeout  time.Duration     `validate:"min=1s,max=10"`
+		Created  time.Time         `validate:"lt"`
+		Tags     []string          `validate:"dive,required"`
+		Labels   map[string]string `validate:"dive,keys,min=1,endkeys,required"`
+		Kind     string            `validate:"required_if=Inner.Name x,required_when=Inner.Name=='a' && ^Kind==nil"`
+		Inner    *Inner
+		Inners   []Inner     `validate:"dive"`
+		Skipped  string      `validate:"-"`
+		Modified string      `mod:"trim"`
+		Any      interface{} `validate:"min=1"`
+	}
+
+	validate := New()
+
+	err := validate.Check(&Good{})
+	NotEqual(t, err, nil)
+
+	// Inner is reached through Good
+	errs := err.(TagErrors)
+	Equal(t, len(errs), 1)
+	Equal(t, errs[0].Type == reflect.TypeOf(Inner{}), true)
+	Equal(t, errs[0].Field, "Price")
+	Equal(t, errs[0].Tag, "gtfield=Cost")
+	Equal(t, errs[0].Problem, "field 'Cost' does not exist")
+	Equal(t, errs[0].Error(), "validator: validator.Inner.Price tag 'gtfield=Cost': field 'Cost' does not exist")
+
+	_, ok := validate.structCache.Get(reflect.TypeOf(Good{}))
+	Equal(t, ok, true)
+
+	_, ok = validate.structCache.Get(reflect.TypeOf(Inner{}))
+	Equal(t, ok, false)
+
+	type Bad struct {
+		Undefined string            `validate:"required,unknown"`
+		Empty     string            `validate:"required,,min=1"`
+		OrTag     string            `validate:"alpha|nope"`
+		MinBool   bool              `validate:"min=1"`
+		MaxParam  int               `validate:"max=ten"`
+		DiveStr   string            `validate:"dive,required"`
+		KeysSlice []string          `validate:"dive,keys,required,endkeys"`
+		OneOf     float64           `validate:"oneof=1 2"`
+		TimeEq    time.Time         `validate:"eq=1"`
+		Pairs     string            `validate:"required_if=Pairs"`
+		With      string            `validate:"required_with=Missing Undefined"`
+		When      string            `validate:"excluded_when=Missing.Name=='a'"`
+		Expr      string            `validate:"required_when=(a"`
+		Mod       string            `mod:"nope"`
+		Values    map[string]string `validate:"dive,max=x"`
+		EqSlice   []string          `validate:"eq=admin"`
+	}
+
+	err = validate.Check(Bad{}, &Good{})
+	NotEqual(t, err, nil)
+
+	errs = err.(TagErrors)
+
+	expected := []string{
+		"validator: validator.Bad.Undefined tag 'unknown': undefined validation function 'unknown'",
+		"validator: validator.Bad.Empty tag '': empty validation tag",
+		"validator: validator.Bad.OrTag tag 'nope': undefined validation function 'nope'",
+		"validator: validator.Bad.MinBool tag 'min=1': 'min' cannot be used on bool",
+		"validator: validator.Bad.MaxParam tag 'max=ten': invalid param 'ten' for int",
+		"validator: validator.Bad.DiveStr tag 'dive': 'dive' cannot be used on string",
+		"validator: validator.Bad.KeysSlice tag 'keys': 'keys' cannot be used on []string",
+		"validator: validator.Bad.OneOf tag 'oneof=1 2': 'oneof' cannot be used on float64",
+		"validator: validator.Bad.TimeEq tag 'eq=1': 'eq' cannot be used on time.Time",
+		"validator: validator.Bad.Pairs tag 'required_if=Pairs': 'required_if' requires field and value pairs",
+		"validator: validator.Bad.With tag 'required_with=Missing Undefined': field 'Missing' does not exist",
+		"validator: validator.Bad.When tag 'excluded_when=Missing.Name=='a'': field 'Missing.Name' does not exist",
+		"validator: validator.Bad.Expr tag 'required_when=(a': Invalid expression '(a' on field 'Expr': missing ')'",
+		"validator: validator.Bad.Mod tag 'nope': Undefined modifier function 'nope' on field 'Mod'",
+		"validator: validator.Bad.Values tag 'max=x': invalid param 'x' for string",
+		"validator: validator.Bad.EqSlice tag 'eq=admin': invalid param 'admin' for []string",
+		"validator: validator.Inner.Price tag 'gtfield=Cost': field 'Cost' does not exist",
+	}
+
+	Equal(t, len(errs), len(expected))
+
+	for i, e := range expected {
+		Equal(t, errs[i].Error(), e)
+	}
+
+	Equal(t, err.Error(), strings.Join(expected, "\n"))
+
+	_, ok = validate.structCache.Get(reflect.TypeOf(Bad{}))
+	Equal(t, ok, false)
+
+	err = validate.Check("string")
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil string)")
+
+	err = validate.Check(nil)
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: (nil)")
+}
+
+func TestStructRules(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string `validate:"len=5"`
+	}
+
+	type User struct {
+		Name    string `validate:"max=5"`
+		Email   string
+		Skipped string `validate:"-"`
+		Address Address
+	}
+
+	validate := New()
+	validate.RegisterStructRules(User{}, map[string]string{"Email": "required,email", "Skipped": "required"})
+	validate.MergeStructRules(&User{}, map[string]string{"Name": "required"})
+
+	cs, ok := validate.structCache.Get(reflect.TypeOf(User{}))
+	Equal(t, ok, false)
+	Equal(t, cs, nil)
+
+	errs := validate.Struct(User{Name: "abcdef", Address: Address{Zip: "1"}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 4)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "max")
+	AssertError(t, errs, "User.Email", "User.Email", "Email", "Email", "required")
+	AssertError(t, errs, "User.Skipped", "User.Skipped", "Skipped", "Skipped", "required")
+	AssertError(t, errs, "User.Address.Zip", "User.Address.Zip", "Zip", "Zip", "len")
+
+	errs = validate.Struct(User{})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "required")
+
+	cs, ok = validate.structCache.Get(reflect.TypeOf(User{}))
+	Equal(t, ok, true)
+	Equal(t, cs.fields[0].cTags.tag, "max")
+	Equal(t, cs.fields[0].cTags.next.tag, "required")
+	Equal(t, cs.fields[1].cTags.tag, "required")
+	Equal(t, cs.fields[1].cTags.next.tag, "email")
+
+	errs = validate.Struct(User{Name: "a", Email: "a@b.com", Skipped: "x", Address: Address{Zip: "12345"}})
+	Equal(t, errs, nil)
+
+	// rules registered once the types are cached are used by the next validations
+	validate.MergeStructRules(User{}, map[string]string{"Skipped": "len=2"})
+	validate.RegisterStructRules(Address{}, map[string]string{"City": "required"})
+
+	_, ok = validate.structCache.Get(reflect.TypeOf(User{}))
+	Equal(t, ok, false)
+
+	errs = validate.Struct(User{Name: "a", Email: "a@b.com", Skipped: "x", Address: Address{Zip: "12345"}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "User.Skipped", "User.Skipped", "Skipped", "Skipped", "len")
+	AssertError(t, errs, "User.Address.City", "User.Address.City", "City", "City", "required")
+
+	// documents
+	validate = New()
+
+	err := validate.RegisterRulesJSON([]byte(`{"User":{"Email":"required,email"},"validator.Address":{"City":"required","Zip":""}}`), User{}, &Address{})
+	Equal(t, err, nil)
+
+	errs = validate.Struct(User{Email: "x", Address: Address{Zip: "1"}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 2)
+	AssertError(t, errs, "User.Email", "User.Email", "Email", "Email", "email")
+	AssertError(t, errs, "User.Address.City", "User.Address.City", "City", "City", "required")
+
+	validate = New()
+
+	err = validate.RegisterRulesDocument([]byte("Address: City=min=2"), func(data []byte, i interface{}) error {
+		vals := strings.SplitN(strings.TrimPrefix(string(data), "Address: "), "=", 2)
+		*i.(*map[string]map[string]string) = map[string]map[string]string{"Address": {vals[0]: vals[1]}}
+		return nil
+	}, Address{})
+	Equal(t, err, nil)
+
+	errs = validate.Struct(Address{City: "a"})
+	NotEqual(t, errs, nil)
+	AssertError(t, errs, "Address.City", "Address.City", "City", "City", "min")
+
+	err = validate.RegisterRulesJSON([]byte(`{"User":{"Email":"email"}`), User{})
+	NotEqual(t, err, nil)
+
+	err = validate.RegisterRulesJSON([]byte(`{"Order":{"ID":"required"}}`), User{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "rules document type 'Order' not found in the types passed in")
+
+	err = validate.RegisterRulesJSON([]byte(`{"User":{"Name":"required"},"Address":{"Street":"required"}}`), User{}, Address{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "Rules registered for field 'Street' not found in struct 'validator.Address'")
+
+	err = validate.RegisterRulesJSON([]byte(`{}`), "string")
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "Rules can only be registered for structs, not 'string'")
+
+	_, ok = validate.structRules[reflect.TypeOf(User{})]["Name"]
+	Equal(t, ok, false)
+
+	PanicMatches(t, func() { validate.RegisterStructRules(1, map[string]string{"Name": "required"}) }, "Rules can only be registered for structs, not 'int'")
+	PanicMatches(t, func() { validate.MergeStructRules(User{}, map[string]string{"City": "required"}) }, "Rules registered for field 'City' not found in struct 'validator.User'")
+
+	// Check uses the registered rules
+	validate = New()
+	validate.RegisterStructRules(User{}, map[string]string{"Email": "required,nope"})
+
+	err = validate.Check(User{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: validator.User.Email tag 'nope': undefined validation function 'nope'")
+}
+
+func TestFor(t *testing.T) {
+	type Address struct {
+		City string
+	}
+
+	type User struct {
+		Name     string `validate:"max=1"`
+		Nick     string
+		Emails   []string
+		Labels   map[string]string
+		Role     string
+		Code     string
+		Password string
+		Confirm  string
+		Address  Address
+		Internal string
+	}
+
+	validate := New()
+	validate.RegisterAlias("role", "oneof=admin 'super user'")
+
+	err := validate.RegisterValidationCtx("code", func(ctx context.Context, fl FieldLevel) bool {
+		return strings.HasPrefix(fl.Field().String(), fl.Param())
+	})
+	Equal(t, err, nil)
+
+	validate.For(&User{}).
+		Field("Name", rules.Required(), rules.Min(3)).
+		Field("Nick", rules.OmitEmpty(), rules.Or(rules.Alpha(), rules.Contains(",|"))).
+		Field("Emails", rules.Required(), rules.Dive(rules.Email())).
+		Field("Labels", rules.Dive(rules.Keys(rules.Min(2)), rules.Required())).
+		Field("Role", rules.Tag("role")).
+		Field("Code", rules.Tag("code", "SKU-")).
+		Field("Internal", rules.Skip()).
+		StructLevel(func(sl StructLevel) {
+			u := sl.Current().Interface().(User)
+			if u.Password != u.Confirm {
+				sl.ReportError(u.Confirm, "Confirm", "Confirm", "confirm", "")
+			}
+		})
+
+	validate.For(Address{}).Field("City", rules.Required())
+
+	user := User{
+		Name:     "ab",
+		Nick:     "a,b",
+		Emails:   []string{"a@b.com", "nope"},
+		Labels:   map[string]string{"a": "b", "bb": ""},
+		Role:     "super",
+		Code:     "ABC",
+		Password: "a",
+		Internal: "ignored",
+	}
+
+	errs := validate.Struct(user)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 9)
+	AssertError(t, errs, "User.Name", "User.Name", "Name", "Name", "min")
+	AssertError(t, errs, "User.Nick", "User.Nick", "Nick", "Nick", "alpha|contains=,|")
+	AssertError(t, errs, "User.Emails[1]", "User.Emails[1]", "Emails[1]", "Emails[1]", "email")
+	AssertError(t, errs, "User.Labels[a]", "User.Labels[a]", "Labels[a]", "Labels[a]", "min")
+	AssertError(t, errs, "User.Labels[bb]", "User.Labels[bb]", "Labels[bb]", "Labels[bb]", "required")
+	AssertError(t, errs, "User.Role", "User.Role", "Role", "Role", "role")
+	AssertError(t, errs, "User.Code", "User.Code", "Code", "Code", "code")
+	AssertError(t, errs, "User.Address.City", "User.Address.City", "City", "City", "required")
+	AssertError(t, errs, "User.Confirm", "User.Confirm", "Confirm", "Confirm", "confirm")
+
+	fe := getError(errs, "User.Code", "User.Code")
+	Equal(t, fe.Param(), "SKU-")
+
+	user = User{
+		Name:     "abc",
+		Nick:     "a,|b",
+		Emails:   []string{"a@b.com"},
+		Labels:   map[string]string{"aa": "b"},
+		Role:     "super user",
+		Code:     "SKU-1",
+		Password: "a",
+		Confirm:  "a",
+		Address:  Address{City: "Paris"},
+	}
+
+	errs = validate.Struct(user)
+	Equal(t, errs, nil)
+
+	PanicMatches(t, func() { validate.For("string") }, "Rules can only be registered for structs, not 'string'")
+	PanicMatches(t, func() { validate.For(User{}).Field("Missing", rules.Required()) }, "Rules registered for field 'Missing' not found in struct 'validator.User'")
+	PanicMatches(t, func() { validate.For(User{}).Field("Name", rules.Tag("nope")) }, "Undefined validation function 'nope' on field 'Name'")
+	PanicMatches(t, func() { validate.For(User{}).Field("Name", rules.Keys(rules.Min(1))) }, "'keys' tag must be immediately preceded by the 'dive' tag")
+}
+
+func TestMap(t *testing.T) {
+	var data map[string]interface{}
+
+	err := json.Unmarshal([]byte(`{
+		"user": {
+			"name": "",
+			"emails": ["a@b.com", "nope"],
+			"addresses": [
+				{"zip": "12345", "city": "Paris"},
+				{"zip": "1"},
+				"home",
+				{"zip": "54321", "city": "Lyon", "extra": true}
+			],
+			"attr_color": "",
+			"attr_size": "L",
+			"age": 12
+		},
+		"tags": {"a": 1},
+		"extra": 1
+	}`), &data)
+	Equal(t, err, nil)
+
+	rules := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":   "required",
+			"emails": "required,dive,email",
+			"addresses": []interface{}{
+				"required,min=1",
+				map[string]interface{}{
+					"zip":  "required,len=5",
+					"city": "required",
+				},
+			},
+			"attr_*":  "required",
+			"phone":   "required",
+			"website": "url",
+			"age":     "gte=18",
+		},
+		"tags":    "required",
+		"profile": map[string]interface{}{"bio": "required"},
+		"extra":   "-",
+	}
+
+	validate := New()
+
+	errs := validate.Map(data, rules)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, len(ve), 8)
+
+	expected := []struct {
+		ns    string
+		field string
+		tag   string
+	}{
+		{ns: "user.addresses[1].city", field: "city", tag: "required"},
+		{ns: "user.addresses[1].zip", field: "zip", tag: "len"},
+		{ns: "user.addresses[2]", field: "addresses[2]", tag: "map"},
+		{ns: "user.age", field: "age", tag: "gte"},
+		{ns: "user.emails[1]", field: "emails[1]", tag: "email"},
+		{ns: "user.name", field: "name", tag: "required"},
+		{ns: "user.phone", field: "phone", tag: "required"},
+		{ns: "user.attr_color", field: "attr_color", tag: "required"},
+	}
+
+	for i, e := range expected {
+		Equal(t, ve[i].Namespace(), e.ns)
+		Equal(t, ve[i].StructNamespace(), e.ns)
+		Equal(t, ve[i].Field(), e.field)
+		Equal(t, ve[i].Tag(), e.tag)
+	}
+
+	Equal(t, ve[2].Value(), "home")
+	Equal(t, ve[2].Kind(), reflect.String)
+
+	// strict
+	errs = validate.MapCtx(WithStrictMaps(context.Background(), true), data, rules)
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 9)
+	AssertError(t, errs, "user.addresses[3].extra", "user.addresses[3].extra", "extra", "extra", "unknown_key")
+
+	validate.SetStrictMaps(true)
+
+	errs = validate.Map(map[string]interface{}{"a": "x", "b": "y", "c": map[string]interface{}{"d": 1}}, map[string]interface{}{"a": "required", "c": map[string]interface{}{}})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "b", "b", "b", "b", "unknown_key")
+	AssertError(t, errs, "c.d", "c.d", "d", "d", "unknown_key")
+
+	fe := getError(errs, "b", "b")
+	Equal(t, fe.Value(), "y")
+
+	errs = validate.MapCtx(WithStrictMaps(context.Background(), false), map[string]interface{}{"a": "x", "b": "y"}, map[string]interface{}{"a": "required"})
+	Equal(t, errs, nil)
+
+	validate.SetStrictMaps(false)
+
+	// limits
+	errs = validate.MapCtx(WithFailFast(context.Background()), data, rules)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+
+	// nested rules on non objects
+	errs = validate.Map(map[string]interface{}{"a": "x", "b": nil}, map[string]interface{}{"a": map[string]interface{}{}, "b": map[string]interface{}{"c": "required"}})
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "a", "a", "a", "a", "map")
+
+	errs = validate.Map(map[string]interface{}{"a": "abc"}, map[string]interface{}{"a": "alpha", "b": "email"})
+	Equal(t, errs, nil)
+
+	// missing keys are validated by conditional required validations
+	conditional := map[string]interface{}{
+		"card":    "required_if=[payment] card",
+		"iban":    "required_unless=[payment] card",
+		"phone":   "required_without=[email]",
+		"zip":     "required_with=[country]",
+		"company": "excluded_with=[payment]",
+		"note":    "required_without_all=[email] [card]",
+	}
+
+	errs = validate.Map(map[string]interface{}{"payment": "card", "email": "a@b.com"}, conditional)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	AssertError(t, errs, "card", "card", "card", "card", "required_if")
+
+	errs = validate.Map(map[string]interface{}{"payment": "cash", "country": "FR"}, conditional)
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 4)
+	AssertError(t, errs, "iban", "iban", "iban", "iban", "required_unless")
+	AssertError(t, errs, "note", "note", "note", "note", "required_without_all")
+	AssertError(t, errs, "phone", "phone", "phone", "phone", "required_without")
+	AssertError(t, errs, "zip", "zip", "zip", "zip", "required_with")
+	Equal(t, ve[0].Value(), nil)
+
+	errs = validate.Map(map[string]interface{}{"payment": "card", "email": "a@b.com", "card": "4242"}, conditional)
+	Equal(t, errs, nil)
+
+	errs = validate.Map("string", rules)
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil string)")
+
+	PanicMatches(t, func() { _ = validate.Map(data, map[string]interface{}{"extra": 1}) }, "Invalid rule of type 'int' for key 'extra'")
+
+	Equal(t, matchWildcard("*", "a"), true)
+	Equal(t, matchWildcard("attr_*", "attr_"), true)
+	Equal(t, matchWildcard("attr_*", "attr"), false)
+	Equal(t, matchWildcard("a*b*c", "aXbYc"), true)
+	Equal(t, matchWildcard("a*b*c", "aXcYb"), false)
+	Equal(t, matchWildcard("a*a", "a"), false)
+}
+
+func TestValidateJSON(t *testing.T) {
+	data := []byte(`{
+  "user": {
+    "name": "",
+    "emails": ["a@b.com", "nope"],
+    "addresses": [
+      {"zip": "12345", "city": "Paris"},
+      {"zip": "1"},
+      "home"
+    ],
+    "attr_color": "",
+    "a/b": 1,
+    "age": 12
+  },
+  "big": {"skipped": [1, 2, {"a": "b"}]},
+  "tags": []
+}`)
+
+	rules := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":   "required",
+			"emails": "required,dive,email",
+			"addresses": []interface{}{
+				"required,max=2",
+				map[string]interface{}{
+					"zip":  "required,len=5",
+					"city": "required",
+				},
+			},
+			"attr_*": "required",
+			"a/b":    "gte=2",
+			"phone":  "required",
+			"age":    "gte=18",
+		},
+		"tags": "required,min=1",
+	}
+
+	validate := New()
+
+	errs := validate.ValidateJSON(data, rules)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+
+	expected := []struct {
+		ns      string
+		pointer string
+		offset  int64
+		tag     string
+	}{
+		{ns: "user.name", pointer: "/user/name", tag: "required"},
+		{ns: "user.emails[1]", pointer: "/user/emails/1", tag: "email"},
+		{ns: "user.addresses", pointer: "/user/addresses", tag: "max"},
+		{ns: "user.addresses[1].zip", pointer: "/user/addresses/1/zip", tag: "len"},
+		{ns: "user.addresses[1].city", pointer: "/user/addresses/1/city", tag: "required"},
+		{ns: "user.addresses[2]", pointer: "/user/addresses/2", tag: "map"},
+		{ns: "user.attr_color", pointer: "/user/attr_color", tag: "required"},
+		{ns: "user.a/b", pointer: "/user/a~1b", tag: "gte"},
+		{ns: "user.age", pointer: "/user/age", tag: "gte"},
+		{ns: "user.phone", pointer: "/user/phone", tag: "required"},
+		{ns: "tags", pointer: "/tags", tag: "min"},
+	}
+
+	Equal(t, len(ve), len(expected))
+
+	for i, e := range expected {
+		fe := ve[i].(JSONFieldError)
+		Equal(t, fe.Namespace(), e.ns)
+		Equal(t, fe.JSONPointer(), e.pointer)
+		Equal(t, fe.Tag(), e.tag)
+	}
+
+	offsetOf := func(s string) int64 {
+		return int64(bytes.Index(data, []byte(s)))
+	}
+
+	Equal(t, ve[0].(JSONFieldError).Offset(), offsetOf(`""`))
+	Equal(t, ve[1].(JSONFieldError).Offset(), offsetOf(`["a@b.com"`))
+	Equal(t, ve[2].(JSONFieldError).Offset(), offsetOf(`[
+      {"zip"`))
+	Equal(t, ve[3].(JSONFieldError).Offset(), offsetOf(`"1"`))
+	Equal(t, ve[4].(JSONFieldError).Offset(), offsetOf(`{"zip": "1"}`))
+	Equal(t, ve[5].(JSONFieldError).Offset(), offsetOf(`"home"`))
+	Equal(t, ve[8].(JSONFieldError).Offset(), offsetOf("12\n"))
+	Equal(t, ve[9].(JSONFieldError).Offset(), offsetOf(`{
+    "name"`))
+
+	Equal(t, ve[1].Value(), "nope")
+	Equal(t, ve[8].Value(), int64(12))
+	Equal(t, ve[8].Field(), "age")
+	Equal(t, strings.HasPrefix(errs.Error(), "Key: 'user.name' Error:Field validation for 'name' failed on the 'required' tag\n"), true)
+
+	// strict
+	errs = validate.ValidateJSONCtx(WithStrictMaps(context.Background(), true), []byte(`{"a": 1, "b": {"c": [1]}, "d": {"e": 2}}`), map[string]interface{}{
+		"a": "required",
+		"d": map[string]interface{}{},
+	})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	AssertError(t, errs, "b", "b", "b", "b", "unknown_key")
+	AssertError(t, errs, "d.e", "d.e", "e", "e", "unknown_key")
+	Equal(t, ve[0].(JSONFieldError).JSONPointer(), "/b")
+	Equal(t, ve[0].(JSONFieldError).Offset(), int64(14))
+	Equal(t, ve[0].Value(), map[string]interface{}{"c": []interface{}{int64(1)}})
+
+	// limits
+	errs = validate.ValidateJSONCtx(WithFailFast(context.Background()), data, rules)
+	NotEqual(t, errs, nil)
+	Equal(t, len(errs.(ValidationErrors)), 1)
+	Equal(t, errs.(ValidationErrors)[0].(JSONFieldError).JSONPointer(), "/user/name")
+
+	errs = validate.ValidateJSON([]byte(`{"a": "abc", "b": null}`), map[string]interface{}{"a": "alpha", "b": map[string]interface{}{"c": "required"}, "c": "email"})
+	Equal(t, errs, nil)
+
+	// bad documents
+	errs = validate.ValidateJSON([]byte(`[1]`), rules)
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil []interface {})")
+
+	errs = validate.ValidateJSON([]byte(`"a"`), rules)
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: (nil string)")
+
+	errs = validate.ValidateJSON([]byte(`{"a": }`), rules)
+	NotEqual(t, errs, nil)
+	_, ok := errs.(ValidationErrors)
+	Equal(t, ok, false)
+
+	errs = validate.ValidateJSON([]byte(`{"a": 1} {}`), map[string]interface{}{})
+	NotEqual(t, errs, nil)
+	Equal(t, errs.Error(), "validator: invalid data after top-level value at offset 9")
+
+	errs = validate.ValidateJSON([]byte(``), rules)
+	Equal(t, errs, io.EOF)
+
+	// numbers are not rounded to a float64
+	data = []byte(`{"id": 9007199254740993, "age": 20, "ratio": 0.1, "price": 12345678901234567.89, "items": [{"qty": 18446744073709551615}, 1.5]}`)
+
+	errs = validate.ValidateJSON(data, map[string]interface{}{
+		"id":    "eq=9007199254740993",
+		"age":   "gte=18",
+		"ratio": "multiple_of=0.05",
+		"price": "max_num=12345678901234567.88",
+		"items": []interface{}{"len=2", map[string]interface{}{"qty": "gt_num=18446744073709551614"}},
+	})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 2)
+	Equal(t, ve[0].Namespace(), "price")
+	Equal(t, ve[0].Tag(), "max_num")
+	Equal(t, ve[0].Value(), json.Number("12345678901234567.89"))
+	Equal(t, ve[1].Namespace(), "items[1]")
+	Equal(t, ve[1].Tag(), "map")
+	Equal(t, ve[1].Value(), 1.5)
+
+	Equal(t, jsonNumberValue("18446744073709551615"), uint64(18446744073709551615))
+	Equal(t, jsonNumberValue("1e400"), json.Number("1e400"))
+
+	Equal(t, namespaceToJSONPointer("[2].zip"), "/2/zip")
+	Equal(t, namespaceToJSONPointer("[a~b][c]"), "/a~0b/c")
+}
+
+func TestValidateJSONTranslate(t *testing.T) {
+	eng := en.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	validate := New()
+
+	err := validate.RegisterTranslation("required", trans, func(ut ut.Translator) error {
+		return ut.Add("required", "{0} is required", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		t, _ := ut.T(fe.Tag(), fe.Field())
+		return t
+	})
+	Equal(t, err, nil)
+
+	rules := map[string]interface{}{
+		"user": map[string]interface{}{"name": "required"},
+	}
+
+	errs := validate.ValidateJSON([]byte(`{"user": {"name": ""}}`), rules)
+	NotEqual(t, errs, nil)
+
+	ve := errs.(ValidationErrors)
+	Equal(t, ve.Translate(trans), ValidationErrorsTranslations{"user.name": "name is required"})
+
+	// errors reported from a struct level validation keep their location
+	type Request struct {
+		Body []byte
+	}
+
+	validate.RegisterStructValidation(func(sl StructLevel) {
+		errs := sl.Validator().ValidateJSON(sl.Current().Interface().(Request).Body, rules)
+		if errs != nil {
+			sl.ReportValidationErrors("Body.", "Body.", errs.(ValidationErrors))
+		}
+	}, Request{})
+
+	errs = validate.Struct(Request{Body: []byte(`{"user": {"name": ""}}`)})
+	NotEqual(t, errs, nil)
+
+	ve = errs.(ValidationErrors)
+	Equal(t, len(ve), 1)
+	AssertError(t, ve, "Request.Body.user.name", "Request.Body.user.name", "name", "name", "required")
+	Equal(t, ve[0].(JSONFieldError).JSONPointer(), "/user/name")
+	Equal(t, ve.Translate(trans), ValidationErrorsTranslations{"Request.Body.user.name": "name is required"})
+}
+
+func TestValidateJSONWarnings(t *testing.T) {
+	data := []byte(`{"user": {"name": "ab", "tags": [{"v": "x"}, {"v": ""}]}}`)
+
+	rules := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name": "required,warn(min=3)",
+			"tags": []interface{}{"warn(max=1)", map[string]interface{}{"v": "warn(required)"}},
+		},
+		"nick": "warn(required)",
+	}
+
+	validate := New()
+
+	var warnings ValidationErrors
+
+	errs := validate.ValidateJSONCtx(withWarnings(context.Background(), &warnings), data, rules)
+	Equal(t, errs, nil)
+	Equal(t, len(warnings), 4)
+
+	fe := warnings[0].(JSONFieldError)
+	Equal(t, fe.Namespace(), "user.name")
+	Equal(t, fe.Tag(), "min")
+	Equal(t, fe.JSONPointer(), "/user/name")
+	Equal(t, fe.Offset(), int64(18))
+
+	// the warnings of a value come before those of its content
+	fe = warnings[1].(JSONFieldError)
+	Equal(t, fe.Namespace(), "user.tags")
+	Equal(t, fe.Tag(), "max")
+	Equal(t, fe.JSONPointer(), "/user/tags")
+
+	fe = warnings[2].(JSONFieldError)
+	Equal(t, fe.Namespace(), "user.tags[1].v")
+	Equal(t, fe.Tag(), "required")
+	Equal(t, fe.JSONPointer(), "/user/tags/1/v")
+
+	fe = warnings[3].(JSONFieldError)
+	Equal(t, fe.Namespace(), "nick")
+	Equal(t, fe.JSONPointer(), "/nick")
+}
+
+func TestWarnings(t *testing.T) {
+	type User struct {
+		Name     string  `validate:"required,warn(min=3)"`
+		Password string  `validate:"required,warn(min=12,containsany=!@#),max=64"`
+		Legacy   string  `validate:"warn(isdefault)"`
+		Color    string  `validate:"warn(rgb|hexcolor)"`
+		Nick     *string `validate:"warn(required),omitempty,max=3"`
+		Email    string  `validate:"email"`
+	}
+
+	validate := New()
+
+	user := User{Name: "ab", Password: "secret", Legacy: "x", Color: "blue", Email: "a@b.com"}
+
+	err := validate.Struct(user)
+	Equal(t, err, nil)
+
+	res := validate.StructResult(user)
+	Equal(t, res.Valid(), true)
+	Equal(t, res.Err(), nil)
+	Equal(t, len(res.Errors), 0)
+	Equal(t, len(res.Warnings), 6)
+
+	AssertError(t, res.Warnings, "User.Name", "User.Name", "Name", "Name", "min")
+	AssertError(t, res.Warnings, "User.Password", "User.Password", "Password", "Password", "min")
+	Equal(t, res.Warnings[2].Namespace(), "User.Password")
+	Equal(t, res.Warnings[2].Tag(), "containsany")
+	AssertError(t, res.Warnings, "User.Legacy", "User.Legacy", "Legacy", "Legacy", "isdefault")
+	AssertError(t, res.Warnings, "User.Color", "User.Color", "Color", "Color", "rgb|hexcolor")
+	AssertError(t, res.Warnings, "User.Nick", "User.Nick", "Nick", "Nick", "required")
+
+	for _, fe := range res.Warnings {
+		Equal(t, fe.Severity(), SeverityWarning)
+	}
+
+	fe := getError(res.Warnings, "User.Name", "User.Name")
+	Equal(t, fe.Error(), "Key: 'User.Name' Warning:Field validation for 'Name' failed on the 'min' tag")
+	Equal(t, fe.Severity().String(), "warning")
+
+	b, e := json.Marshal(fe)
+	Equal(t, e, nil)
+	Equal(t, strings.Contains(string(b), `"severity":"warning"`), true)
+
+	nick := "abcd"
+	user = User{Name: "abc", Password: "secret!secret", Nick: &nick, Email: "x"}
+
+	res = validate.StructResult(&user)
+	Equal(t, res.Valid(), false)
+	Equal(t, len(res.Errors), 2)
+	Equal(t, len(res.Warnings), 1)
+	AssertError(t, res.Errors, "User.Nick", "User.Nick", "Nick", "Nick", "max")
+	AssertError(t, res.Errors, "User.Email", "User.Email", "Email", "Email", "email")
+	AssertError(t, res.Warnings, "User.Color", "User.Color", "Color", "Color", "rgb|hexcolor")
+
+	fe = getError(res.Errors, "User.Email", "User.Email")
+	Equal(t, fe.Severity(), SeverityError)
+	Equal(t, fe.Severity().String(), "error")
+
+	err = res.Err()
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 2)
+
+	err = validate.Struct(user)
+	NotEqual(t, err, nil)
+	Equal(t, len(err.(ValidationErrors)), 2)
+
+	// warnings are neither kept between validations nor counted as errors
+	res = validate.StructResultCtx(WithFailFast(context.Background()), User{Name: "ab", Password: "secret", Email: "x"})
+	Equal(t, len(res.Errors), 1)
+	Equal(t, len(res.Warnings), 5)
+
+	res = validate.VarResult("ab", "warn(min=3),max=5")
+	Equal(t, res.Valid(), true)
+	Equal(t, len(res.Warnings), 1)
+	AssertError(t, res.Warnings, "", "", "", "", "min")
+
+	res = validate.VarResult("abcdef", "warn(min=3),max=5")
+	Equal(t, res.Valid(), false)
+	Equal(t, len(res.Warnings), 0)
+
+	res = validate.StructResult(1)
+	Equal(t, res.Valid(), false)
+	Equal(t, res.Err().Error(), "validator: (nil int)")
+
+	// translations
+	eng := en.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	err = validate.RegisterTranslation("min", trans, func(ut ut.Translator) error {
+		return ut.Add("min", "{0} is too short", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		t, _ := ut.T(fe.Tag(), fe.Field())
+		return t
+	})
+	Equal(t, err, nil)
+
+	err = validate.RegisterTranslation("warn(min)", trans, func(ut ut.Translator) error {
+		return ut.Add("warn(min)", "{0} should be longer", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		t, _ := ut.T("warn(min)", fe.Field())
+		return t
+	})
+	Equal(t, err, nil)
+
+	res = validate.VarResult("ab", "warn(min=3),min=3")
+	Equal(t, res.Warnings[0].Translate(trans), " should be longer")
+	Equal(t, res.Errors[0].Translate(trans), " is too short")
+
+	res = validate.VarResult("", "warn(required)")
+	Equal(t, res.Warnings[0].Translate(trans), "Key: '' Warning:Field validation for '' failed on the 'required' tag")
+
+	PanicMatches(t, func() { _ = validate.Var([]string{}, "warn(dive,required)") }, "Invalid tag 'dive' within 'warn' on field ''")
+	PanicMatches(t, func() { _ = validate.Var("", "warn()") }, "Invalid validation tag on field ''")
+
+	// Check reports undefined tags within warn
+	type Bad struct {
+		Name string `validate:"warn(nope)"`
+	}
+
+	err = validate.Check(Bad{})
+	NotEqual(t, err, nil)
+	Equal(t, err.Error(), "validator: validator.Bad.Name tag 'nope': undefined validation function 'nope'")
+
+	Equal(t, splitTags("a,warn(b,c),d"), []string{"a", "warn(b,c)", "d"})
+	Equal(t, splitTags("contains=(,min=1"), []string{"contains=(", "min=1"})
+	Equal(t, splitTags("contains=(,contains=)"), []string{"contains=(", "contains=)"})
+	Equal(t, splitTags("(len=3,numeric),!(min=1,max=2),a"), []string{"(len=3,numeric)", "!(min=1,max=2)", "a"})
+	Equal(t, splitTags("startswith=A|(len=3,numeric),min=1"), []string{"startswith=A|(len=3,numeric)", "min=1"})
+	Equal(t, splitTags("contains=(x),warn(min=1,max=2)"), []string{"contains=(x)", "warn(min=1,max=2)"})
+
+	// parentheses within params don't group tags
+	type Parens struct {
+		Name string `validate:"contains=(,contains=)"`
+	}
+
+	Equal(t, validate.Struct(Parens{Name: "(a)"}), nil)
+
+	err = validate.Struct(Parens{Name: "(a"})
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "Parens.Name", "Parens.Name", "Name", "Name", "contains")
+}
+
+func TestMessages(t *testing.T) {
+	type User struct {
+		Name  string   `validate:"required,min=3" msg:"{field} is invalid" msg_min:"{field} needs at least {param} characters, '{value}' is too short" errcode:"NAME_INVALID" errcode_min:"NAME_SHORT"`
+		Email string   `json:"email" validate:"required,email" errcode:"EMAIL_INVALID"`
+		Color string   `validate:"iscolor" msg_iscolor:"{value} is not a {tag}"`
+		Tags  []string `validate:"dive,max=2" msg_max:"tag {field} is too long"`
+		Plain string   `validate:"required"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	err := validate.Struct(User{Name: "ab", Email: "x", Color: "red", Tags: []string{"a", "abc"}})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+
+	fe := getError(errs, "User.Name", "User.Name")
+	Equal(t, fe.Message(), "Name needs at least 3 characters, 'ab' is too short")
+	Equal(t, fe.Code(), "NAME_SHORT")
+
+	fe = getError(errs, "User.email", "User.Email")
+	Equal(t, fe.Message(), "")
+	Equal(t, fe.Code(), "EMAIL_INVALID")
+
+	fe = getError(errs, "User.Color", "User.Color")
+	Equal(t, fe.Message(), "red is not a iscolor")
+	Equal(t, fe.Code(), "")
+
+	fe = getError(errs, "User.Tags[1]", "User.Tags[1]")
+	Equal(t, fe.Message(), "tag Tags[1] is too long")
+
+	fe = getError(errs, "User.Plain", "User.Plain")
+	Equal(t, fe.Message(), "")
+	Equal(t, fe.Code(), "")
+
+	err = validate.Struct(User{Email: "a@b.com", Plain: "x"})
+	NotEqual(t, err, nil)
+
+	fe = getError(err.(ValidationErrors), "User.Name", "User.Name")
+	Equal(t, fe.Message(), "Name is invalid")
+	Equal(t, fe.Code(), "NAME_INVALID")
+
+	// translations are overridden by the message
+	eng := en.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	err = validate.RegisterTranslation("required", trans, func(ut ut.Translator) error {
+		return ut.Add("required", "{0} is required", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		t, _ := ut.T(fe.Tag(), fe.Field())
+		return t
+	})
+	Equal(t, err, nil)
+
+	err = validate.Struct(User{})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, getError(errs, "User.Name", "User.Name").Translate(trans), "Name is invalid")
+	Equal(t, getError(errs, "User.Plain", "User.Plain").Translate(trans), "Plain is required")
+
+	docs := errs.Documents(nil, false)
+	Equal(t, docs[0].Message, "Name is invalid")
+	Equal(t, docs[0].Code, "NAME_INVALID")
+	Equal(t, docs[1].Code, "EMAIL_INVALID")
+	Equal(t, docs[1].Message, "Key: 'User.email' Error:Field validation for 'email' failed on the 'required' tag")
+
+	m := parseMessages(`json:"name" msgs:"x" msg_:"y" errcodes:"z"`)
+	Equal(t, m, nil)
+
+	m = parseMessages(`msg:"a" errcode_required_if:"b"`)
+	Equal(t, m.messages[""], "a")
+	Equal(t, m.codes["required_if"], "b")
+}
+
+func TestErrorTree(t *testing.T) {
+	type Address struct {
+		Zip  string `json:"zip" validate:"required"`
+		City string `json:"city" validate:"required,min=3"`
+	}
+
+	type User struct {
+		Name      string             `json:"name" validate:"required"`
+		Addresses []Address          `json:"addresses" validate:"min=2,dive"`
+		Attrs     map[string]string  `json:"attrs" validate:"dive,min=2"`
+		Items     []string           `json:"items" validate:"dive,len=1"`
+		Contacts  map[string]Address `json:"contacts" validate:"dive"`
+	}
+
+	validate := New()
+	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	items := make([]string, 11)
+	for i := range items {
+		items[i] = "x"
+	}
+	items[2], items[10] = "", ""
+
+	u := User{
+		Addresses: []Address{{Zip: "1", City: "Oslo"}},
+		Attrs:     map[string]string{"a/b": "x"},
+		Items:     items,
+		Contacts:  map[string]Address{"home": {Zip: "2", City: "x"}},
+	}
+
+	err := validate.Struct(u)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 6)
+
+	fe := getError(errs, "User.addresses", "User.Addresses")
+	Equal(t, fe.JSONPointer(), "/addresses")
+	Equal(t, getError(errs, "User.attrs[a/b]", "User.Attrs[a/b]").JSONPointer(), "/attrs/a~1b")
+	Equal(t, getError(errs, "User.contacts[home].city", "User.Contacts[home].City").JSONPointer(), "/contacts/home/city")
+	Equal(t, getError(errs, "User.items[10]", "User.Items[10]").JSONPointer(), "/items/10")
+
+	b, err := json.Marshal(errs.Tree())
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"addresses":["min"],"attrs":{"a/b":["min"]},"contacts":{"home":{"city":["min"]}},"items":[null,null,["len"],null,null,null,null,null,null,null,["len"]],"name":["required"]}`)
+
+	tree := errs.Tree()
+	Equal(t, len(tree.Errors), 0)
+	Equal(t, tree.Children["items"].Children["10"].Errors[0].Tag(), "len")
+
+	byField := errs.ByField()
+	Equal(t, len(byField), 6)
+	Equal(t, len(byField["User.name"]), 1)
+
+	filtered := errs.Filter("User.items")
+	Equal(t, len(filtered), 2)
+	Equal(t, len(errs.Filter("User.item")), 0)
+	Equal(t, len(errs.Filter("User.contacts[home]")), 1)
+	Equal(t, len(errs.Filter("")), 6)
+
+	sorted := errs.Merge()
+	sorted.Sort()
+	Equal(t, sorted[0].Namespace(), "User.addresses")
+	Equal(t, sorted[1].Namespace(), "User.attrs[a/b]")
+	Equal(t, sorted[2].Namespace(), "User.contacts[home].city")
+	Equal(t, sorted[3].Namespace(), "User.items[2]")
+	Equal(t, sorted[4].Namespace(), "User.items[10]")
+	Equal(t, sorted[5].Namespace(), "User.name")
+
+	// nested errors along with errors of their own
+	u.Addresses = nil
+	u.Name = "x"
+	u.Items = nil
+	u.Attrs = nil
+
+	err = validate.Struct(u)
+	NotEqual(t, err, nil)
+
+	other := validate.Var("", "required")
+	NotEqual(t, other, nil)
+
+	merged := errs.Merge(err.(ValidationErrors), other.(ValidationErrors))
+	Equal(t, len(merged), 7)
+	Equal(t, merged[6].Namespace(), "")
+	Equal(t, merged[6].JSONPointer(), "")
+
+	b, err = json.Marshal(merged.Filter("User.contacts").Tree())
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"contacts":{"home":{"city":["min"]}}}`)
+
+	b, err = json.Marshal(ValidationErrors{merged[0], merged[6]}.Tree())
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"_errors":["required"],"name":["required"]}`)
+
+	// errors of a ValidateJSON keep their JSON Pointer
+	err = validate.ValidateJSON([]byte(`{"user":{"addresses":[{"zip":""}]}}`), map[string]interface{}{
+		"user": map[string]interface{}{
+			"addresses": map[string]interface{}{"zip": "required"},
+		},
+	})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, errs[0].JSONPointer(), "/user/addresses/0/zip")
+
+	b, err = json.Marshal(errs.Tree())
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"user":{"addresses":[{"zip":["required"]}]}}`)
+
+	// maps keyed by integers render as objects, whatever their keys
+	type Scores struct {
+		ByID   map[int]string   `json:"by_id" validate:"dive,required"`
+		Matrix [][]string       `json:"matrix" validate:"dive,dive,required"`
+		Nested map[int][]string `json:"nested" validate:"dive,dive,required"`
+	}
+
+	err = validate.Struct(Scores{
+		ByID:   map[int]string{4000000000000: "", 1: ""},
+		Matrix: [][]string{{"x"}, {"x", ""}},
+		Nested: map[int][]string{0: {""}},
+	})
+	NotEqual(t, err, nil)
+
+	b, err = json.Marshal(err.(ValidationErrors).Tree())
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"by_id":{"1":["required"],"4000000000000":["required"]},"matrix":[null,[null,["required"]]],"nested":{"0":[["required"]]}}`)
+
+	Equal(t, isIndex("0"), true)
+	Equal(t, isIndex("01"), false)
+	Equal(t, isIndex("-1"), false)
+}
+
+type optionalString struct {
+	value string
+	set   bool
+}
+
+func (o optionalString) IsSet() bool      { return o.set }
+func (o optionalString) Get() interface{} { return o.value }
+
+type optionalInt struct {
+	value int
+	set   bool
+}
+
+func (o *optionalInt) IsSet() bool      { return o.set }
+func (o *optionalInt) Get() interface{} { return o.value }
+
+type failingValuer struct {
+	Name string `validate:"required"`
+}
+
+func (failingValuer) Value() (driver.Value, error) { return nil, errors.New("fail") }
+
+type jsonAddress struct {
+	Zip string `validate:"required,len=5"`
+}
+
+func (a jsonAddress) Value() (driver.Value, error) { return json.Marshal(a) }
+
+func TestWrappers(t *testing.T) {
+	type Test struct {
+		Name     sql.NullString  `validate:"required,min=3"`
+		Nick     sql.NullString  `validate:"omitempty,min=3"`
+		Age      sql.NullInt64   `validate:"omitempty,gte=18"`
+		Score    sql.NullFloat64 `validate:"required,lte=10"`
+		Born     sql.NullTime    `validate:"required"`
+		Color    optionalString  `validate:"omitempty,iscolor"`
+		Count    optionalInt     `validate:"required,max=5"`
+		Failing  failingValuer
+		Address  jsonAddress
+		Pointer  *sql.NullString `validate:"omitempty,min=3"`
+		Interval sql.NullInt32   `validate:"gtfield=Age"`
+	}
+
+	validate := New()
+
+	err := validate.Struct(Test{})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 7)
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "required")
+	AssertError(t, errs, "Test.Score", "Test.Score", "Score", "Score", "required")
+	AssertError(t, errs, "Test.Born", "Test.Born", "Born", "Born", "required")
+	AssertError(t, errs, "Test.Count", "Test.Count", "Count", "Count", "required")
+	AssertError(t, errs, "Test.Failing.Name", "Test.Failing.Name", "Name", "Name", "required")
+	AssertError(t, errs, "Test.Address.Zip", "Test.Address.Zip", "Zip", "Zip", "required")
+	AssertError(t, errs, "Test.Interval", "Test.Interval", "Interval", "Interval", "gtfield")
+
+	s := Test{
+		Name:     sql.NullString{String: "ab", Valid: true},
+		Nick:     sql.NullString{String: "x"},
+		Age:      sql.NullInt64{Int64: 16, Valid: true},
+		Score:    sql.NullFloat64{Float64: 11, Valid: true},
+		Born:     sql.NullTime{Time: time.Now(), Valid: true},
+		Color:    optionalString{value: "red", set: true},
+		Count:    optionalInt{value: 6, set: true},
+		Failing:  failingValuer{Name: "x"},
+		Address:  jsonAddress{Zip: "75001"},
+		Pointer:  &sql.NullString{String: "ab", Valid: true},
+		Interval: sql.NullInt32{Int32: 20, Valid: true},
+	}
+
+	err = validate.Struct(s)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 6)
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "min")
+	AssertError(t, errs, "Test.Age", "Test.Age", "Age", "Age", "gte")
+	AssertError(t, errs, "Test.Score", "Test.Score", "Score", "Score", "lte")
+	AssertError(t, errs, "Test.Color", "Test.Color", "Color", "Color", "iscolor")
+	AssertError(t, errs, "Test.Count", "Test.Count", "Count", "Count", "max")
+	AssertError(t, errs, "Test.Pointer", "Test.Pointer", "Pointer", "Pointer", "min")
+
+	fe := getError(errs, "Test.Name", "Test.Name")
+	Equal(t, fe.Value(), "ab")
+	Equal(t, fe.Kind(), reflect.String)
+
+	s.Name.String = "abc"
+	s.Age.Int64 = 18
+	s.Score.Float64 = 10
+	s.Color.value = "#fff"
+	s.Count.value = 5
+	s.Pointer.Valid = false
+
+	err = validate.Struct(s)
+	Equal(t, err, nil)
+
+	// a set empty string is validated as an empty string
+	err = validate.Var(sql.NullString{Valid: true}, "required")
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "", "", "", "", "required")
+
+	Equal(t, validate.Var(sql.NullString{}, "omitempty,email"), nil)
+	Equal(t, validate.Var(sql.NullString{String: "a@b.co", Valid: true}, "email"), nil)
+
+	// custom type funcs take precedence
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return "custom"
+	}, sql.NullString{})
+
+	err = validate.Var(sql.NullString{}, "eq=custom")
+	Equal(t, err, nil)
+
+	// tags are not checked against the kind of wrapped values
+	type Checked struct {
+		Age sql.NullInt64 `validate:"min=18"`
+	}
+
+	Equal(t, validate.Check(Checked{}), nil)
+}
+
+type celsius struct {
+	degrees float64
+}
+
+func (c celsius) Value() (driver.Value, error) { return c.degrees, nil }
+
+type nullCelsius struct {
+	celsius *celsius
+}
+
+func (c *nullCelsius) Value() (driver.Value, error) {
+	if c.celsius == nil {
+		return nil, nil
+	}
+	return c.celsius.Value()
+}
+
+// conditionalTest returns a struct whose Name field has the type and tag, followed by
+// the Other and Missing strings.
+func conditionalTest(typ reflect.Type, tag, other string) interface{} {
+	s := reflect.New(reflect.StructOf([]reflect.StructField{
+		{Name: "Name", Type: typ, Tag: reflect.StructTag(`validate:"` + tag + `"`)},
+		{Name: "Other", Type: reflect.TypeOf("")},
+		{Name: "Missing", Type: reflect.TypeOf("")},
+	})).Elem()
+
+	s.Field(1).SetString(other)
+
+	return s.Interface()
+}
+
+func TestWrappersConditionalTags(t *testing.T) {
+	tags := []string{
+		"required_if=Other x",
+		"required_unless=Other y",
+		"required_with=Other",
+		"required_with_all=Other",
+		"required_without=Missing",
+		"required_without_all=Missing",
+		"excluded_if=Other x",
+		"excluded_unless=Other y",
+		"excluded_with=Other",
+		"excluded_with_all=Other",
+		"excluded_without=Missing",
+		"excluded_without_all=Missing",
+		"required_when=Other == 'x'",
+	}
+
+	validate := New()
+
+	for _, tag := range tags {
+		for _, other := range []string{"", "x"} {
+
+			null := validate.Struct(conditionalTest(reflect.TypeOf(sql.NullString{}), tag, other))
+			nilPtr := validate.Struct(conditionalTest(reflect.TypeOf((*string)(nil)), tag, other))
+
+			if (null == nil) != (nilPtr == nil) {
+				t.Errorf("%s with Other %q: null value got %v, nil pointer got %v", tag, other, null, nilPtr)
+			}
+		}
+	}
+
+	// a required null value fails, a set one is validated using the tags
+	type Test struct {
+		Name  sql.NullString `validate:"required_if=Other x,omitempty,min=3"`
+		Other string
+	}
+
+	err := validate.Struct(Test{Other: "x"})
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "Test.Name", "Test.Name", "Name", "Name", "required_if")
+
+	Equal(t, validate.Struct(Test{}), nil)
+
+	err = validate.Struct(Test{Name: sql.NullString{String: "ab", Valid: true}})
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "Test.Name", "Test.Name", "Name", "Name", "min")
+}
+
+func TestWrappersValuer(t *testing.T) {
+	type Test struct {
+		Temp    celsius      `validate:"lte=100"`
+		Night   nullCelsius  `validate:"omitempty,gte=-20"`
+		Day     nullCelsius  `validate:"required"`
+		Address jsonAddress  `validate:"required"`
+		Temps   []celsius    `validate:"dive,gte=0"`
+		Ptr     *nullCelsius `validate:"omitempty,lte=0"`
+	}
+
+	validate := New()
+
+	err := validate.Struct(Test{
+		Temp:    celsius{degrees: 120},
+		Night:   nullCelsius{celsius: &celsius{degrees: -30}},
+		Address: jsonAddress{Zip: "1"},
+		Temps:   []celsius{{degrees: 1}, {degrees: -1}},
+		Ptr:     &nullCelsius{celsius: &celsius{degrees: 1}},
+	})
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 6)
+	AssertError(t, errs, "Test.Temp", "Test.Temp", "Temp", "Temp", "lte")
+	AssertError(t, errs, "Test.Night", "Test.Night", "Night", "Night", "gte")
+	AssertError(t, errs, "Test.Day", "Test.Day", "Day", "Day", "required")
+	AssertError(t, errs, "Test.Address.Zip", "Test.Address.Zip", "Zip", "Zip", "len")
+	AssertError(t, errs, "Test.Temps[1]", "Test.Temps[1]", "Temps[1]", "Temps[1]", "gte")
+	AssertError(t, errs, "Test.Ptr", "Test.Ptr", "Ptr", "Ptr", "lte")
+
+	fe := getError(errs, "Test.Temp", "Test.Temp")
+	Equal(t, fe.Value(), float64(120))
+	Equal(t, fe.Kind(), reflect.Float64)
+
+	err = validate.Struct(Test{
+		Temp:    celsius{degrees: 20},
+		Day:     nullCelsius{celsius: &celsius{degrees: 25}},
+		Address: jsonAddress{Zip: "75001"},
+	})
+	Equal(t, err, nil)
+}
+
+func TestTimeValidations(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC) // a Friday
+
+	validate := New()
+	validate.SetClock(func() time.Time { return now })
+
+	type Event struct {
+		Start    time.Time `validate:"future,within=72h"`
+		End      time.Time `validate:"after=now+24h,before=2024-04-01"`
+		Created  time.Time `validate:"past,after=2024-01-01T00:00:00Z"`
+		Day      time.Time `validate:"date_only,weekday=mon-fri"`
+		Weekend  string    `validate:"weekday=sat sun"`
+		Date     string    `validate:"date_only"`
+		Paris    time.Time `validate:"timezone_is=Europe/Paris"`
+		Deadline time.Time `validate:"gt"`
+	}
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	Equal(t, err, nil)
+
+	e := Event{
+		Start:    now.Add(48 * time.Hour),
+		End:      now.Add(25 * time.Hour),
+		Created:  now.Add(-time.Hour),
+		Day:      time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC),
+		Weekend:  "2024-03-17T10:00:00Z",
+		Date:     "2024-03-15",
+		Paris:    now.In(paris),
+		Deadline: now.Add(time.Second),
+	}
+
+	err = validate.Struct(e)
+	Equal(t, err, nil)
+
+	e = Event{
+		Start:    now.Add(73 * time.Hour),
+		End:      now.Add(23 * time.Hour),
+		Created:  now.Add(time.Hour),
+		Day:      time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC),
+		Weekend:  "2024-03-15T10:00:00Z",
+		Date:     "2024-03-15T10:00:00Z",
+		Paris:    now,
+		Deadline: now,
+	}
+
+	err = validate.Struct(e)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 8)
+	AssertError(t, errs, "Event.Start", "Event.Start", "Start", "Start", "within")
+	AssertError(t, errs, "Event.End", "Event.End", "End", "End", "after")
+	AssertError(t, errs, "Event.Created", "Event.Created", "Created", "Created", "past")
+	AssertError(t, errs, "Event.Day", "Event.Day", "Day", "Day", "weekday")
+	AssertError(t, errs, "Event.Weekend", "Event.Weekend", "Weekend", "Weekend", "weekday")
+	AssertError(t, errs, "Event.Date", "Event.Date", "Date", "Date", "date_only")
+	AssertError(t, errs, "Event.Paris", "Event.Paris", "Paris", "Paris", "timezone_is")
+	AssertError(t, errs, "Event.Deadline", "Event.Deadline", "Deadline", "Deadline", "gt")
+
+	// the clock of the context takes precedence
+	ctx := WithClock(context.Background(), func() time.Time { return now.Add(-96 * time.Hour) })
+
+	err = validate.VarCtx(ctx, now, "future")
+	Equal(t, err, nil)
+
+	err = validate.VarCtx(ctx, now, "within=72h")
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "", "", "", "", "within")
+
+	Equal(t, validate.Var(now.Add(-time.Minute), "within=-1h"), nil)
+	NotEqual(t, validate.Var(now.Add(time.Minute), "past"), nil)
+
+	Equal(t, validate.Var(now, "before=now+1s,after=now-1s"), nil)
+	Equal(t, validate.Var(now, "weekday=fri-mon"), nil)
+	Equal(t, validate.Var(now.Add(24*time.Hour), "weekday=fri-mon"), nil)
+	NotEqual(t, validate.Var(now.Add(-24*time.Hour), "weekday=fri-mon"), nil)
+	NotEqual(t, validate.Var("not a time", "future"), nil)
+	Equal(t, validate.Var(now, "timezone_is=UTC"), nil)
+	Equal(t, validate.Var(now.In(time.FixedZone("", 3600)), "timezone_is=Europe/Paris"), nil)
+	NotEqual(t, validate.Var(now.Add(time.Nanosecond), "date_only"), nil)
+
+	PanicMatches(t, func() { _ = validate.Var(now, "weekday=mon-fry") }, "Bad weekday 'fry' in param 'mon-fry'")
+	PanicMatches(t, func() { _ = validate.Var(now, "after=yesterday") }, "Bad time param 'yesterday'")
+	PanicMatches(t, func() { _ = validate.Var(now, "after=nowish") }, "Bad time param 'nowish'")
+	PanicMatches(t, func() { _ = validate.Var(1, "future") }, "Bad field type int")
+}
+
+func TestNumericValidations(t *testing.T) {
+	type Payment struct {
+		Amount   string      `validate:"decimal=10 2,gt_num=0,max_num=99999999.99"`
+		Number   json.Number `validate:"min_num=0.01,multiple_of=0.05"`
+		Price    float64     `validate:"multiple_of=0.01,lt_num=100"`
+		Quantity int64       `validate:"min_num=1,max_num=9223372036854775807"`
+		Total    *big.Int    `validate:"required,gt_num=18446744073709551615"`
+		Rate     *big.Rat    `validate:"omitempty,min_num=0,lt_num=1"`
+		Balance  *big.Float  `validate:"decimal=30 4"`
+		Shares   big.Int     `validate:"omitempty,multiple_of=100"`
+	}
+
+	validate := New()
+
+	p := Payment{
+		Amount:   "99999999.99",
+		Number:   "10.05",
+		Price:    19.99,
+		Quantity: 1,
+		Total:    new(big.Int).Lsh(big.NewInt(1), 64),
+		Rate:     big.NewRat(1, 3),
+		Balance:  big.NewFloat(1234.5625),
+	}
+
+	err := validate.Struct(p)
+	Equal(t, err, nil)
+
+	p = Payment{
+		Amount:   "100000000.00",
+		Number:   "10.03",
+		Price:    19.999,
+		Quantity: 0,
+		Total:    new(big.Int).SetUint64(math.MaxUint64),
+		Rate:     big.NewRat(1, 1),
+		Balance:  big.NewFloat(1234.56251),
+	}
+	p.Shares.SetInt64(150)
+
+	err = validate.Struct(p)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 8)
+	AssertError(t, errs, "Payment.Amount", "Payment.Amount", "Amount", "Amount", "decimal")
+	AssertError(t, errs, "Payment.Number", "Payment.Number", "Number", "Number", "multiple_of")
+	AssertError(t, errs, "Payment.Price", "Payment.Price", "Price", "Price", "multiple_of")
+	AssertError(t, errs, "Payment.Quantity", "Payment.Quantity", "Quantity", "Quantity", "min_num")
+	AssertError(t, errs, "Payment.Total", "Payment.Total", "Total", "Total", "gt_num")
+	AssertError(t, errs, "Payment.Rate", "Payment.Rate", "Rate", "Rate", "lt_num")
+	AssertError(t, errs, "Payment.Balance", "Payment.Balance", "Balance", "Balance", "decimal")
+	AssertError(t, errs, "Payment.Shares", "Payment.Shares", "Shares", "Shares", "multiple_of")
+
+	err = validate.Struct(Payment{Amount: "abc", Number: "1e99999", Total: nil})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	AssertError(t, errs, "Payment.Amount", "Payment.Amount", "Amount", "Amount", "decimal")
+	AssertError(t, errs, "Payment.Number", "Payment.Number", "Number", "Number", "min_num")
+	AssertError(t, errs, "Payment.Total", "Payment.Total", "Total", "Total", "required")
+
+	// no float rounding
+	NotEqual(t, validate.Var("0.30000000000000001", "max_num=0.3"), nil)
+	a, b := 0.1, 0.2
+	NotEqual(t, validate.Var(a+b, "decimal=5 2"), nil)
+	Equal(t, validate.Var(0.3, "multiple_of=0.1"), nil)
+	Equal(t, validate.Var("1.2e3", "multiple_of=100,decimal=4 0"), nil)
+	Equal(t, validate.Var("-12.5", "decimal=3 1,lt_num=-12.49"), nil)
+	Equal(t, validate.Var(uint64(math.MaxUint64), "gt_num=9223372036854775807"), nil)
+	Equal(t, validate.Var("12.", "decimal=2 0"), nil)
+	NotEqual(t, validate.Var("123", "decimal=2 0"), nil)
+
+	PanicMatches(t, func() { _ = validate.Var("1", "decimal=2") }, "Bad decimal param '2', expected precision and scale eg. 'decimal=10 2'")
+	PanicMatches(t, func() { _ = validate.Var("1", "decimal=2 3") }, "Bad decimal param '2 3', expected precision and scale eg. 'decimal=10 2'")
+	PanicMatches(t, func() { _ = validate.Var("1", "min_num=x") }, "Bad number param 'x'")
+	PanicMatches(t, func() { _ = validate.Var("1", "multiple_of=0") }, "Bad number param '0'")
+	PanicMatches(t, func() { _ = validate.Var(true, "min_num=1") }, "Bad field type bool")
+}
+
+func TestTagGroups(t *testing.T) {
+	type Contact struct {
+		Login    string  `validate:"(email|e164),!contains=test"`
+		Password string  `validate:"(len=0|min=8),!(alpha|numeric)"`
+		Color    string  `validate:"omitempty,!iscolor"`
+		Code     string  `validate:"required,startswith=A|(len=3,numeric)"`
+		Nick     *string `validate:"omitempty,(len=0|min=3)"`
+	}
+
+	validate := New()
+
+	nick := "ab"
+
+	c := Contact{
+		Login:    "test@example.com",
+		Password: "abcdefgh",
+		Color:    "#fff",
+		Code:     "12a",
+		Nick:     &nick,
+	}
+
+	err := validate.Struct(c)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "Contact.Login", "Contact.Login", "Login", "Login", "!contains")
+	AssertError(t, errs, "Contact.Password", "Contact.Password", "Password", "Password", "!(alpha|numeric)")
+	AssertError(t, errs, "Contact.Color", "Contact.Color", "Color", "Color", "!iscolor")
+	AssertError(t, errs, "Contact.Code", "Contact.Code", "Code", "Code", "startswith=A|(len=3,numeric)")
+	AssertError(t, errs, "Contact.Nick", "Contact.Nick", "Nick", "Nick", "len=0|min=3")
+
+	fe := getError(errs, "Contact.Login", "Contact.Login")
+	Equal(t, fe.ActualTag(), "!contains")
+	Equal(t, fe.Param(), "test")
+
+	fe = getError(errs, "Contact.Color", "Contact.Color")
+	Equal(t, fe.ActualTag(), "!iscolor")
+
+	c = Contact{
+		Login:    "+33612345678",
+		Password: "abcd1234",
+		Code:     "123",
+	}
+
+	err = validate.Struct(c)
+	Equal(t, err, nil)
+
+	c.Code = "Abc"
+	c.Password = ""
+	c.Login = "nope"
+
+	err = validate.Struct(c)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Contact.Login", "Contact.Login", "Login", "Login", "email|e164")
+
+	// the failing tag of an and within a group is reported
+	err = validate.Var("1234", "!(len=4,numeric)|startswith=0")
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "", "", "", "", "!(len=4,numeric)|startswith=0")
+
+	err = validate.Var("12345", "(numeric,len=4)")
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "", "", "", "", "len")
+	Equal(t, err.(ValidationErrors)[0].Param(), "4")
+
+	eng := en.New()
+	uni := ut.New(eng, eng)
+	trans, _ := uni.GetTranslator("en")
+
+	Equal(t, validate.RegisterTranslation("len", trans, func(ut ut.Translator) error {
+		return ut.Add("len", "{0} must be {1} characters long", false)
+	}, func(ut ut.Translator, fe FieldError) string {
+		t, _ := ut.T(fe.Tag(), fe.Field(), fe.Param())
+		return t
+	}), nil)
+
+	type Pin struct {
+		Code string `validate:"(numeric,len=4)"`
+	}
+
+	err = validate.Struct(Pin{Code: "12345"})
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Translate(trans), "Code must be 4 characters long")
+
+	Equal(t, validate.Var("a,b", "(contains=0x2C|contains=0x7C)"), nil)
+	Equal(t, validate.Var("12", "!!numeric"), nil)
+
+	// aliases within groups and groups within aliases
+	validate.RegisterAlias("strong", "(len=0|min=8),!alpha")
+	validate.RegisterAlias("phone", "e164")
+
+	err = validate.Var("abcdefgh", "strong")
+	NotEqual(t, err, nil)
+	fe = err.(ValidationErrors)[0]
+	Equal(t, fe.Tag(), "strong")
+	Equal(t, fe.ActualTag(), "!alpha")
+
+	err = validate.Var("x", "(email|phone)")
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Tag(), "email|phone")
+
+	err = validate.Var("x", "!phone|(phone)")
+	Equal(t, err, nil)
+
+	err = validate.Var("+33612345678", "!phone")
+	NotEqual(t, err, nil)
+	fe = err.(ValidationErrors)[0]
+	Equal(t, fe.Tag(), "!phone")
+	Equal(t, fe.ActualTag(), "!phone")
+
+	err = validate.Var("abc", "(phone,len=3)")
+	NotEqual(t, err, nil)
+	fe = err.(ValidationErrors)[0]
+	Equal(t, fe.Tag(), "phone")
+	Equal(t, fe.ActualTag(), "e164")
+
+	// translations and messages of the tags within groups
+	type Account struct {
+		Email string `validate:"(required,email)" msg_email:"{field} must be an e-mail"`
+	}
+
+	err = validate.Struct(Account{Email: "x"})
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Message(), "Email must be an e-mail")
+
+	// warnings
+	res := validate.VarResult("abc", "warn((len=0|min=8)),alpha")
+	Equal(t, res.Valid(), true)
+	Equal(t, len(res.Warnings), 1)
+	Equal(t, res.Warnings[0].Tag(), "len=0|min=8")
+
+	Equal(t, validate.Check(Contact{}), nil)
+
+	PanicMatches(t, func() { _ = validate.Var("a", "(email|e164") }, "Invalid tag group '(email|e164' on field '': missing ')'")
+	PanicMatches(t, func() { _ = validate.Var("a", "(email|e164))") }, "Invalid tag group '(email|e164))' on field '': unexpected ')' at 12")
+	PanicMatches(t, func() { _ = validate.Var("a", "!(email|)") }, "Invalid tag group '!(email|)' on field '': empty tag")
+	PanicMatches(t, func() { _ = validate.Var("a", "!(dive,email)") }, "Invalid tag group '!(dive,email)' on field '': 'dive' can't be used within a group")
+	PanicMatches(t, func() { _ = validate.Var("a", "!emial") }, "Undefined validation function 'emial' on field ''")
+}
+
+func TestParameterizedAliases(t *testing.T) {
+	validate := New()
+	validate.RegisterAlias("range", "min=$1,max=$2")
+	validate.RegisterAlias("percent", "range=0 100")
+	validate.RegisterAlias("code", "len=$1,startswith=$2|startswith=$3")
+	validate.RegisterAlias("color", "iscolor")
+
+	type Test struct {
+		Age     int     `validate:"range=18 130"`
+		Rate    float64 `validate:"percent"`
+		Code    string  `validate:"code=4 A B"`
+		Name    string  `validate:"range=2 5,alpha"`
+		Quoted  string  `validate:"code=3 'x' y"`
+		Grouped int     `validate:"!range=1 3|eq=2"`
+		Color   string  `validate:"color"`
+	}
+
+	s := Test{Age: 17, Rate: 101, Code: "C123", Name: "abcdef", Quoted: "xyz", Grouped: 3, Color: "#fff"}
+
+	err := validate.Struct(s)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+	AssertError(t, errs, "Test.Age", "Test.Age", "Age", "Age", "range")
+	AssertError(t, errs, "Test.Rate", "Test.Rate", "Rate", "Rate", "range")
+	AssertError(t, errs, "Test.Code", "Test.Code", "Code", "Code", "code")
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "range")
+	AssertError(t, errs, "Test.Grouped", "Test.Grouped", "Grouped", "Grouped", "!range=1 3|eq=2")
+
+	fe := getError(errs, "Test.Age", "Test.Age")
+	Equal(t, fe.ActualTag(), "min")
+	Equal(t, fe.Param(), "18")
+
+	fe = getError(errs, "Test.Rate", "Test.Rate")
+	Equal(t, fe.ActualTag(), "max")
+	Equal(t, fe.Param(), "100")
+
+	fe = getError(errs, "Test.Code", "Test.Code")
+	Equal(t, fe.ActualTag(), "startswith=A|startswith=B")
+
+	fe = getError(errs, "Test.Name", "Test.Name")
+	Equal(t, fe.ActualTag(), "max")
+	Equal(t, fe.Param(), "5")
+
+	s = Test{Age: 18, Rate: 100, Code: "B123", Name: "ab", Quoted: "xyz", Grouped: 2, Color: "#fff"}
+	Equal(t, validate.Struct(s), nil)
+
+	err = validate.Var(5, "range=1 3")
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "", "", "", "", "range")
+
+	err = validate.Var(2, "(range=3 5|eq=1)")
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Tag(), "range=3 5|eq=1")
+
+	err = validate.Var(4, "(eq=4,range=1 3)")
+	NotEqual(t, err, nil)
+	fe = err.(ValidationErrors)[0]
+	Equal(t, fe.Tag(), "range")
+	Equal(t, fe.ActualTag(), "max")
+	Equal(t, fe.Param(), "3")
+
+	Equal(t, validate.Check(Test{}), nil)
+
+	PanicMatches(t, func() { _ = validate.Var(1, "range") }, "Alias 'range' expects 2 params, got 0 on field ''")
+	PanicMatches(t, func() { _ = validate.Var(1, "range=1") }, "Alias 'range' expects 2 params, got 1 on field ''")
+	PanicMatches(t, func() { _ = validate.Var(1, "range=1 2 3") }, "Alias 'range' expects 2 params, got 3 on field ''")
+	PanicMatches(t, func() { _ = validate.Var("a", "color=1") }, "Alias 'color' takes no params on field ''")
+}
+
+func TestParamReferences(t *testing.T) {
+
+	type Plan struct {
+		Allowed []string
+		Window  time.Duration
+	}
+
+	type Test struct {
+		MaxItems int
+		Plan     *Plan
+		Items    []string      `validate:"max=${MaxItems},dive,oneof=${Plan.Allowed}"`
+		Name     string        `validate:"max=@{limits.name_len}"`
+		Timeout  time.Duration `validate:"lte=${Plan.Window}"`
+		Score    float64       `validate:"(lte=@{limits.score}|eq=-1)"`
+	}
+
+	validate := New()
+
+	limits := ParamMap(map[string]interface{}{
+		"limits": map[string]interface{}{
+			"name_len": 5,
+			"score":    float32(0.5),
+		},
+	})
+
+	ctx := WithParamSource(context.Background(), limits)
+
+	s := Test{
+		MaxItems: 2,
+		Plan:     &Plan{Allowed: []string{"a", "b c"}, Window: time.Minute},
+		Items:    []string{"a", "b c"},
+		Name:     "abcde",
+		Timeout:  time.Minute,
+		Score:    0.5,
+	}
+	Equal(t, validate.StructCtx(ctx, s), nil)
+
+	s.Items = []string{"a", "b", "b c"}
+	s.Name = "abcdef"
+	s.Timeout = time.Hour
+	s.Score = 0.6
+
+	err := validate.StructCtx(ctx, s)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 4)
+	AssertError(t, errs, "Test.Items", "Test.Items", "Items", "Items", "max")
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "max")
+	AssertError(t, errs, "Test.Timeout", "Test.Timeout", "Timeout", "Timeout", "lte")
+	AssertError(t, errs, "Test.Score", "Test.Score", "Score", "Score", "lte=@{limits.score}|eq=-1")
+
+	Equal(t, getError(errs, "Test.Items", "Test.Items").Param(), "2")
+	Equal(t, getError(errs, "Test.Name", "Test.Name").Param(), "5")
+	Equal(t, getError(errs, "Test.Timeout", "Test.Timeout").Param(), "1m0s")
+
+	s.Items = []string{"a", "b"}
+	s.Name = "abc"
+	s.Timeout = time.Second
+	s.Score = -1
+
+	err = validate.StructCtx(ctx, s)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 1)
+	AssertError(t, errs, "Test.Items[1]", "Test.Items[1]", "Items[1]", "Items[1]", "oneof")
+	Equal(t, errs[0].Param(), "a 'b c'")
+
+	// resolved params are not cached
+	oneofValsCacheRWLock.RLock()
+	_, cached := oneofValsCache["a 'b c'"]
+	oneofValsCacheRWLock.RUnlock()
+	Equal(t, cached, false)
+
+	// references that can't be resolved fail
+	s.Items = []string{"a"}
+	s.Plan = nil
+
+	err = validate.Struct(s)
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 3)
+	AssertError(t, errs, "Test.Items[0]", "Test.Items[0]", "Items[0]", "Items[0]", "oneof")
+	AssertError(t, errs, "Test.Name", "Test.Name", "Name", "Name", "max")
+	AssertError(t, errs, "Test.Timeout", "Test.Timeout", "Timeout", "Timeout", "lte")
+	Equal(t, getError(errs, "Test.Name", "Test.Name").Param(), "@{limits.name_len}")
+
+	// custom validations get the resolved param
+	validate.RegisterValidation("prefix", func(fl FieldLevel) bool {
+		return strings.HasPrefix(fl.Field().String(), fl.Param())
+	})
+
+	type Prefixed struct {
+		Prefix string
+		Value  string `validate:"prefix=${Prefix}"`
+	}
+
+	Equal(t, validate.Struct(Prefixed{Prefix: "ab", Value: "abc"}), nil)
+	NotEqual(t, validate.Struct(Prefixed{Prefix: "b", Value: "abc"}), nil)
+
+	err = validate.VarCtx(WithParamSource(context.Background(), func(key string) (interface{}, bool) {
+		return 3, key == "max"
+	}), "abcd", "max=@{max}")
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Param(), "3")
+
+	Equal(t, validate.Check(Test{}), nil)
+
+	type Unknown struct {
+		Value int `validate:"max=${Missing}"`
+	}
+
+	NotEqual(t, validate.Check(Unknown{}), nil)
+
+	// params starting by '$' or '@' without braces are literals
+	type Literals struct {
+		Email  string `validate:"endswith=@example.com"`
+		Domain string `validate:"contains=@corp.io"`
+		Price  string `validate:"startswith=$USD"`
+	}
+
+	l := Literals{Email: "john@example.com", Domain: "jane@corp.io", Price: "$USD 10"}
+	Equal(t, validate.Struct(l), nil)
+	Equal(t, validate.Check(Literals{}), nil)
+
+	err = validate.Struct(Literals{Email: "john@example.org", Domain: "jane@corp.io", Price: "$USD 10"})
+	NotEqual(t, err, nil)
+	AssertError(t, err.(ValidationErrors), "Literals.Email", "Literals.Email", "Email", "Email", "endswith")
+	Equal(t, err.(ValidationErrors)[0].Param(), "@example.com")
+}
+
+type tagMethodSKU string
+
+func (s tagMethodSKU) Valid() bool {
+	return strings.HasPrefix(string(s), "SKU-")
+}
+
+type tagMethodTenant string
+
+func (t *tagMethodTenant) Check(ctx context.Context) error {
+	if string(*t) != ctx.Value(tagMethodCtxKey{}) {
+		return errors.New("unknown tenant")
+	}
+	return nil
+}
+
+type tagMethodCtxKey struct{}
+
+type tagMethodOrder struct {
+	Kind    string
+	SKU     string           `validate:"method=CheckSKU"`
+	SKUs    []tagMethodSKU   `validate:"min=1,dive,method=Valid"`
+	Tenant  *tagMethodTenant `validate:"method=Check"`
+	Comment string           `validate:"omitempty,(method=CheckComment|len=0)"`
+}
+
+func (o tagMethodOrder) CheckSKU() error {
+	if o.Kind == "digital" && !strings.HasPrefix(o.SKU, "D") {
+		return errors.New("digital SKUs start with D")
+	}
+	return nil
+}
+
+func (o *tagMethodOrder) CheckComment() bool {
+	return len(o.Comment) < 10
+}
+
+func TestMethodValidation(t *testing.T) {
+
+	validate := New()
+
+	ctx := context.WithValue(context.Background(), tagMethodCtxKey{}, "acme")
+
+	tenant := tagMethodTenant("acme")
+
+	o := tagMethodOrder{
+		Kind:    "digital",
+		SKU:     "D-1",
+		SKUs:    []tagMethodSKU{"SKU-1", "SKU-2"},
+		Tenant:  &tenant,
+		Comment: "short",
+	}
+	Equal(t, validate.StructCtx(ctx, o), nil)
+	Equal(t, validate.StructCtx(ctx, &o), nil)
+
+	o.SKU = "P-1"
+	o.SKUs = []tagMethodSKU{"SKU-1", "X-2"}
+	tenant = "other"
+	o.Comment = "far too long"
+
+	err := validate.StructCtx(ctx, o)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 4)
+	AssertError(t, errs, "tagMethodOrder.SKU", "tagMethodOrder.SKU", "SKU", "SKU", "method")
+	AssertError(t, errs, "tagMethodOrder.SKUs[1]", "tagMethodOrder.SKUs[1]", "SKUs[1]", "SKUs[1]", "method")
+	AssertError(t, errs, "tagMethodOrder.Tenant", "tagMethodOrder.Tenant", "Tenant", "Tenant", "method")
+	AssertError(t, errs, "tagMethodOrder.Comment", "tagMethodOrder.Comment", "Comment", "Comment", "method=CheckComment|len=0")
+
+	fe := getError(errs, "tagMethodOrder.SKU", "tagMethodOrder.SKU")
+	Equal(t, fe.Param(), "CheckSKU")
+
+	// methods of the types of variables
+	Equal(t, validate.Var(tagMethodSKU("SKU-3"), "method=Valid"), nil)
+	NotEqual(t, validate.Var(tagMethodSKU("3"), "method=Valid"), nil)
+
+	Equal(t, validate.Check(tagMethodOrder{}), nil)
+
+	type Missing struct {
+		Name string `validate:"method=Unknown"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(Missing{}) }, "Method 'Unknown' not found on 'validator.Missing' or the type of field 'Name'")
+	NotEqual(t, validate.Check(Missing{}), nil)
+
+	type BadSignature struct {
+		Value time.Duration `validate:"method=Round"`
+	}
+
+	PanicMatches(t, func() { _ = validate.Struct(BadSignature{}) }, "Bad method 'Round' of 'time.Duration', expected func() bool, func() error or func(context.Context) error")
+
+	PanicMatches(t, func() { _ = validate.Var("a", "method=Valid") }, "Method 'Valid' not found on 'string' or the type of field ''")
+}
+
+type passwordError struct {
+	Code string
+	Min  int
+}
+
+func (e *passwordError) Error() string {
+	return fmt.Sprintf("%s: at least %d", e.Code, e.Min)
+}
+
+func TestValidationCause(t *testing.T) {
+
+	errDigit := errors.New("missing digit")
+
+	validate := New()
+	err := validate.RegisterValidationErr("password", func(ctx context.Context, fl FieldLevel) error {
+
+		s := fl.Field().String()
+
+		if len(s) < 8 {
+			return &passwordError{Code: "too_short", Min: 8}
+		}
+
+		if !strings.ContainsAny(s, "0123456789") {
+			return fmt.Errorf("password: %w", errDigit)
+		}
+
+		return nil
+	})
+	Equal(t, err, nil)
+
+	en := en.New()
+	uni := ut.New(en, en)
+	trans, _ := uni.GetTranslator("en")
+
+	err = validate.RegisterTranslation("password", trans,
+		func(ut ut.Translator) error {
+			return ut.Add("password", "{0} must have at least {1} characters", false)
+		}, func(ut ut.Translator, fe FieldError) string {
+
+			var pe *passwordError
+			if !errors.As(fe.Cause(), &pe) {
+				return fe.Error()
+			}
+
+			t, _ := ut.T(fe.Tag(), fe.Field(), fmt.Sprint(pe.Min))
+			return t
+		})
+	Equal(t, err, nil)
+
+	type Test struct {
+		Password  string   `validate:"password"`
+		Passwords []string `validate:"dive,password"`
+		Either    string   `validate:"(password|eq=admin)"`
+		Grouped   string   `validate:"(len=8,password)"`
+	}
+
+	s := Test{
+		Password:  "short",
+		Passwords: []string{"nodigits", "secret123", "short"},
+		Either:    "root",
+		Grouped:   "password",
+	}
+
+	err = validate.Struct(s)
+	NotEqual(t, err, nil)
+
+	errs := err.(ValidationErrors)
+	Equal(t, len(errs), 5)
+
+	fe := getError(errs, "Test.Password", "Test.Password")
+	NotEqual(t, fe, nil)
+	Equal(t, fe.Translate(trans), "Password must have at least 8 characters")
+
+	var pe *passwordError
+	Equal(t, errors.As(fe, &pe), true)
+	Equal(t, pe.Code, "too_short")
+
+	fe = getError(errs, "Test.Passwords[0]", "Test.Passwords[0]")
+	Equal(t, errors.Is(fe, errDigit), true)
+	Equal(t, fe.Translate(trans), fe.Error())
+
+	fe = getError(errs, "Test.Passwords[2]", "Test.Passwords[2]")
+	Equal(t, errors.As(fe.Cause(), &pe), true)
+
+	fe = getError(errs, "Test.Either", "Test.Either")
+	Equal(t, fe.Tag(), "password|eq=admin")
+	Equal(t, fe.Cause(), nil)
+
+	fe = getError(errs, "Test.Grouped", "Test.Grouped")
+	Equal(t, fe.Tag(), "password")
+	Equal(t, errors.Is(fe, errDigit), true)
+
+	b, err := json.Marshal(getError(errs, "Test.Password", "Test.Password"))
+	Equal(t, err, nil)
+	Equal(t, strings.Contains(string(b), `"cause":"too_short: at least 8"`), true)
+
+	// errors of the methods of the 'method' tag
+	o := tagMethodOrder{Kind: "digital", SKU: "P-1", SKUs: []tagMethodSKU{"SKU-1"}}
+
+	err = validate.Struct(o)
+	NotEqual(t, err, nil)
+
+	fe = err.(ValidationErrors)[0]
+	Equal(t, fe.Tag(), "method")
+	NotEqual(t, fe.Cause(), nil)
+	Equal(t, fe.Cause().Error(), "digital SKUs start with D")
+
+	// validations returning bool have no cause
+	err = validate.Var("", "required")
+	NotEqual(t, err, nil)
+	Equal(t, err.(ValidationErrors)[0].Cause(), nil)
+	Equal(t, errors.Unwrap(err.(ValidationErrors)[0]), nil)
+
+	// the cause of a previous run of the tag is not kept when its param can't be resolved
+	err = validate.RegisterValidationErr("maxlen", func(ctx context.Context, fl FieldLevel) error {
+		if max := asInt(fl.Param()); int64(len(fl.Field().String())) > max {
+			return &passwordError{Code: "too_long", Min: int(max)}
+		}
+		return nil
+	})
+	Equal(t, err, nil)
+
+	type Limited struct {
+		Limit *int
+		Name  string `validate:"maxlen=${Limit}"`
+		Alt   string `validate:"eq=x|maxlen=${Limit}"`
+		Group string `validate:"(maxlen=${Limit},alpha)"`
+	}
+
+	type Limits struct {
+		Items []Limited `validate:"dive"`
+	}
+
+	limit := 3
+
+	err = validate.Struct(Limits{Items: []Limited{
+		{Limit: &limit, Name: "long", Alt: "long", Group: "long"},
+		{Name: "long", Alt: "long", Group: "long"},
+	}})
+	NotEqual(t, err, nil)
+
+	errs = err.(ValidationErrors)
+	Equal(t, len(errs), 6)
+
+	for _, fe := range errs[:3] {
+		NotEqual(t, fe.Cause(), nil)
+	}
+
+	for _, fe := range errs[3:] {
+		Equal(t, fe.Namespace()[:14], "Limits.Items[1")
+		Equal(t, fe.Cause(), nil)
+	}
+}
+
+type wrappedFieldError struct {
+	FieldError
+}
+
+type methodErrors struct {
+	errs ValidationErrors
+}
+
+func (m methodErrors) Validate() error {
+	return m.errs
+}
+
+func TestValidateMethodErrorsCopied(t *testing.T) {
+
+	type Outer struct {
+		Inner methodErrors
+	}
+
+	returned := ValidationErrors{
+		&fieldError{tag: "required", actualTag: "required", ns: "Name", structNs: "Name", fieldLen: 4, structfieldLen: 4},
+		wrappedFieldError{&fieldError{tag: "min", actualTag: "min", ns: "Age", structNs: "Age", fieldLen: 3, structfieldLen: 3, param: "1"}},
+	}
+
+	validate := New()
+
+	for i := 0; i < 2; i++ {
+
+		err := validate.Struct(Outer{Inner: methodErrors{errs: returned}})
+		NotEqual(t, err, nil)
+
+		errs := err.(ValidationErrors)
+		Equal(t, len(errs), 2)
+		AssertError(t, errs, "Outer.Inner.Name", "Outer.Inner.Name", "Name", "Name", "required")
+		AssertError(t, errs, "Outer.Inner.Age", "Outer.Inner.Age", "Age", "Age", "min")
+		Equal(t, getError(errs, "Outer.Inner.Age", "Outer.Inner.Age").Param(), "1")
+	}
+
+	// the errors returned by the method are left as they are
+	Equal(t, returned[0].Namespace(), "Name")
+	Equal(t, returned[1].Namespace(), "Age")
+}
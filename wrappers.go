@@ -0,0 +1,155 @@
+package validator
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// Optional is implemented by wrappers of values that may not be set. A value that
+// is not set is validated like a nil pointer, eg. failing 'required' and skipping
+// the tags following 'omitempty', while the value of a set one is validated using
+// the tags of the field.
+type Optional interface {
+	// IsSet reports whether the value is set.
+	IsSet() bool
+
+	// Get returns the value.
+	Get() interface{}
+}
+
+// wrapper is how the values of a type wrap the value that is validated.
+type wrapper uint8
+
+const (
+	noWrapper wrapper = iota
+	optionalWrapper
+	valuerWrapper
+)
+
+var (
+	optionalType = reflect.TypeOf((*Optional)(nil)).Elem()
+	valuerType   = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// wrapperEntry is the cached wrapper of a type, see wrapperOf.
+type wrapperEntry struct {
+	w     wrapper
+	onPtr bool
+}
+
+// wrapperOf returns how the values of the type wrap the value that is validated,
+// and whether the methods of the wrapper are those of the pointer to the type.
+//
+// Optional values are unwrapped whatever their kind, and so are the values of the
+// types implementing driver.Valuer eg. sql.NullString, except slices, arrays, maps and
+// structs having validations of their own, such as those stored as JSON, which keep
+// being validated as they are.
+func (v *Validate) wrapperOf(typ reflect.Type) (wrapper, bool) {
+
+	if e, ok := v.wrappers.Load(typ); ok {
+		return e.(wrapperEntry).w, e.(wrapperEntry).onPtr
+	}
+
+	var e wrapperEntry
+
+	switch {
+	case typ.Implements(optionalType):
+		e.w = optionalWrapper
+	case reflect.PtrTo(typ).Implements(optionalType):
+		e.w, e.onPtr = optionalWrapper, true
+	case !typ.Implements(valuerType) && !reflect.PtrTo(typ).Implements(valuerType):
+	case typ.Kind() == reflect.Slice, typ.Kind() == reflect.Array, typ.Kind() == reflect.Map:
+	case typ.Kind() == reflect.Struct && v.hasStructValidations(typ):
+	default:
+		e.w, e.onPtr = valuerWrapper, !typ.Implements(valuerType)
+	}
+
+	v.wrappers.Store(typ, e)
+
+	return e.w, e.onPtr
+}
+
+// mayWrap reports whether the values of the type, once dereferenced, may be wrappers
+// or converted by a custom type func, which is only known when validating interfaces.
+func (v *Validate) mayWrap(typ reflect.Type) bool {
+
+	typ = derefType(typ)
+
+	if typ.Kind() == reflect.Interface {
+		return true
+	}
+
+	if _, ok := v.customFuncs[typ]; ok {
+		return true
+	}
+
+	w, _ := v.wrapperOf(typ)
+
+	return w != noWrapper
+}
+
+// hasStructValidations reports whether the struct type has validations of its own,
+// tagged fields, rules registered for its fields or a struct level validation.
+func (v *Validate) hasStructValidations(typ reflect.Type) bool {
+
+	if _, ok := v.structLevelFuncs[typ]; ok {
+		return true
+	}
+
+	if len(v.structRules[typ]) > 0 {
+		return true
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if tag := typ.Field(i).Tag.Get(v.tagName); len(tag) > 0 && tag != skipValidationTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unwrap returns the value wrapped by current, an invalid value when it is null, and
+// whether current is a wrapper at all.
+func (v *Validate) unwrap(current reflect.Value) (reflect.Value, bool) {
+
+	if !current.CanInterface() {
+		return current, false
+	}
+
+	w, onPtr := v.wrapperOf(current.Type())
+	if w == noWrapper {
+		return current, false
+	}
+
+	recv := current
+
+	if onPtr {
+		if current.CanAddr() {
+			recv = current.Addr()
+		} else {
+			recv = reflect.New(current.Type())
+			recv.Elem().Set(current)
+		}
+	}
+
+	switch w {
+	case optionalWrapper:
+
+		opt := recv.Interface().(Optional)
+		if !opt.IsSet() {
+			return reflect.Value{}, true
+		}
+
+		return reflect.ValueOf(opt.Get()), true
+
+	default:
+
+		val, err := recv.Interface().(driver.Valuer).Value()
+		if err != nil {
+			return current, false
+		}
+
+		return reflect.ValueOf(val), true
+	}
+}